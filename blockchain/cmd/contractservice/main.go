@@ -1,11 +1,13 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -13,12 +15,17 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/Ajay6601/smart-contract-intelligence/internal/audit"
+	"github.com/Ajay6601/smart-contract-intelligence/internal/auth"
 	"github.com/Ajay6601/smart-contract-intelligence/internal/contracts"
 	"github.com/Ajay6601/smart-contract-intelligence/internal/eth"
 	"github.com/Ajay6601/smart-contract-intelligence/internal/library"
+	"github.com/Ajay6601/smart-contract-intelligence/internal/webhooks"
 )
 
 func main() {
+	allowInsecureKey := flag.Bool("allow-insecure-key", false, "allow signing with a plaintext ETH_PRIVATE_KEY env var instead of a keystore or clef signer (development only)")
+	flag.Parse()
+
 	// Load environment variables
 	err := godotenv.Load()
 	if err != nil {
@@ -52,19 +59,62 @@ func main() {
 	contractsCollection := db.Collection("contracts")
 	templatesCollection := db.Collection("templates")
 	auditsCollection := db.Collection("audits")
+	bundlesCollection := db.Collection("deployment_bundles")
+	eventsCollection := db.Collection("contract_events")
+	eventCheckpointsCollection := db.Collection("contract_event_checkpoints")
+	webhooksCollection := db.Collection("webhooks")
+	webhookDeliveriesCollection := db.Collection("webhook_deliveries")
+
+	// Build the signer used to authorize deployments and writes. ETH_SIGNER_MODE
+	// selects the backend: "keystore" (default), "clef", or "env" (plaintext,
+	// requires --allow-insecure-key).
+	signer, err := buildSigner(*allowInsecureKey)
+	if err != nil {
+		log.Fatalf("Failed to initialize Ethereum signer: %v", err)
+	}
 
 	// Initialize Ethereum client
-	ethClient, err := eth.NewEthClient(os.Getenv("ETH_RPC_URL"))
+	ethClient, err := eth.NewEthClient(os.Getenv("ETH_RPC_URL"), signer)
 	if err != nil {
 		log.Fatalf("Failed to connect to Ethereum node: %v", err)
 	}
 	log.Println("Connected to Ethereum node")
 
-	// Initialize services
-	contractService := contracts.NewService(contractsCollection, ethClient)
+	// Initialize services. CHAIN_REGISTRY_PATH points at a JSON file
+	// describing every chain /deploy's chain_ids may target; without it,
+	// multi-chain deployment requests fail with "no chain registry
+	// configured" but everything else still works.
+	var contractService *contracts.Service
+	if chainRegistry, err := contracts.LoadChainRegistry(os.Getenv("CHAIN_REGISTRY_PATH")); err != nil {
+		log.Printf("Warning: chain registry not loaded, multi-chain deployments are disabled: %v", err)
+		contractService = contracts.NewService(contractsCollection, ethClient)
+	} else {
+		contractService = contracts.NewServiceWithChainRegistry(contractsCollection, ethClient, chainRegistry)
+	}
+	contractService.SetBundleCollection(bundlesCollection)
+	contractService.SetEventsCollection(eventsCollection)
+	contractService.SetCheckpointCollection(eventCheckpointsCollection)
+
+	eventIndexer := contracts.NewEventIndexer(contractService, 15*time.Second)
+	contractService.SetIndexer(eventIndexer)
+	go eventIndexer.Run(context.Background())
+
+	webhookService := webhooks.NewService(webhooksCollection, webhookDeliveriesCollection)
+	contractService.SetWebhookService(webhookService)
+	go webhookService.RunDeliveryWorker(context.Background(), 5*time.Second)
+
 	auditService := audit.NewService(auditsCollection, ethClient)
 	libraryService := library.NewService(templatesCollection)
 
+	jwksURL := os.Getenv("AUTH_JWKS_URL")
+	if jwksURL == "" {
+		log.Fatal("AUTH_JWKS_URL environment variable is required")
+	}
+	verifier, err := auth.NewJWKSVerifier(context.Background(), jwksURL, 10*time.Minute)
+	if err != nil {
+		log.Fatalf("Failed to initialize JWKS verifier: %v", err)
+	}
+
 	// Set up Gin router
 	router := gin.Default()
 
@@ -92,7 +142,7 @@ func main() {
 	})
 
 	// Set up API routes
-	setupRoutes(router, contractService, auditService, libraryService)
+	setupRoutes(router, contractService, auditService, libraryService, webhookService, verifier)
 
 	// Start the server
 	port := os.Getenv("PORT")
@@ -110,17 +160,34 @@ func setupRoutes(
 	contractService *contracts.Service,
 	auditService *audit.Service,
 	libraryService *library.Service,
+	webhookService *webhooks.Service,
+	verifier *auth.JWKSVerifier,
 ) {
+	readAuth := auth.Middleware(verifier, auth.ScopeContractsRead)
+	writeAuth := auth.Middleware(verifier, auth.ScopeContractsWrite)
+	deployAuth := auth.Middleware(verifier, auth.ScopeContractsDeploy)
+
 	// Contract routes
-	router.POST("/contracts", contractService.CreateContract)
-	router.GET("/contracts", contractService.ListContracts)
-	router.GET("/contracts/:id", contractService.GetContract)
-	router.PUT("/contracts/:id", contractService.UpdateContract)
-	router.DELETE("/contracts/:id", contractService.DeleteContract)
+	router.POST("/contracts", writeAuth, contractService.CreateContract)
+	router.GET("/contracts", readAuth, contractService.ListContracts)
+	router.GET("/contracts/:id", readAuth, contractService.GetContract)
+	router.PUT("/contracts/:id", writeAuth, contractService.UpdateContract)
+	router.DELETE("/contracts/:id", writeAuth, contractService.DeleteContract)
+	router.POST("/contracts/:id/collaborators", writeAuth, contractService.AddCollaborator)
+	router.DELETE("/contracts/:id/collaborators/:userId", writeAuth, contractService.RemoveCollaborator)
 
 	// Deployment routes
-	router.POST("/deploy", contractService.DeployContract)
-	router.GET("/deploy/:txHash", contractService.GetDeploymentStatus)
+	router.POST("/deploy", deployAuth, contractService.DeployContract)
+	router.GET("/deploy/:txHash", readAuth, contractService.GetDeploymentStatus)
+	router.GET("/deploy/:txHash/await", readAuth, contractService.AwaitDeployment)
+	router.POST("/deploy/bundle", deployAuth, contractService.DeployBundle)
+	router.GET("/deploy/bundle/:id", readAuth, contractService.GetBundleStatus)
+
+	// Event routes
+	router.GET("/contracts/:id/events", readAuth, contractService.GetContractEvents)
+	router.POST("/contracts/:id/events/subscribe", readAuth, contractService.SubscribeEvents)
+	router.GET("/contracts/:id/events/stream", readAuth, contractService.StreamEvents)
+	router.GET("/contracts/:id/events/ws", readAuth, contractService.StreamEventsLive)
 
 	// Verification routes
 	router.POST("/verify", auditService.VerifyContract)
@@ -131,4 +198,37 @@ func setupRoutes(
 	router.GET("/library", libraryService.ListTemplates)
 	router.GET("/library/:id", libraryService.GetTemplate)
 	router.POST("/library", libraryService.CreateTemplate)
+
+	// Webhook routes
+	router.POST("/webhooks", writeAuth, webhookService.RegisterWebhook)
+	router.GET("/webhooks", readAuth, webhookService.ListWebhooks)
+	router.DELETE("/webhooks/:id", writeAuth, webhookService.DeleteWebhook)
+	router.GET("/admin/webhooks/deliveries/dead-letter", writeAuth, webhookService.ListDeadLetters)
+	router.POST("/admin/webhooks/deliveries/:id/replay", writeAuth, webhookService.ReplayDelivery)
+}
+
+// buildSigner constructs the eth.Signer used for deployments, based on
+// ETH_SIGNER_MODE:
+//   - "keystore" (default): unlock ETH_SIGNER_ADDRESS from the JSON
+//     keyfiles in ETH_KEYSTORE_DIR using ETH_KEYSTORE_PASSPHRASE.
+//   - "clef": delegate signing to a remote clef instance at CLEF_ENDPOINT
+//     for account ETH_SIGNER_ADDRESS.
+//   - "env": sign with a plaintext ETH_PRIVATE_KEY; only allowed when
+//     allowInsecureKey is set.
+func buildSigner(allowInsecureKey bool) (eth.Signer, error) {
+	switch os.Getenv("ETH_SIGNER_MODE") {
+	case "clef":
+		return eth.NewClefSigner(
+			os.Getenv("CLEF_ENDPOINT"),
+			common.HexToAddress(os.Getenv("ETH_SIGNER_ADDRESS")),
+		)
+	case "env":
+		return eth.NewPlaintextEnvSigner(allowInsecureKey)
+	default:
+		return eth.NewKeystoreSigner(
+			os.Getenv("ETH_KEYSTORE_DIR"),
+			common.HexToAddress(os.Getenv("ETH_SIGNER_ADDRESS")),
+			os.Getenv("ETH_KEYSTORE_PASSPHRASE"),
+		)
+	}
 }
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwk is a single RSA key as published in a JWKS document.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwksDocument struct {
+	Keys []jwk `json:"keys"`
+}
+
+// JWKSVerifier validates RS256-signed JWTs against keys published by a
+// JWKS endpoint, refreshing the key set periodically so key rotation on the
+// identity provider doesn't require a service restart.
+type JWKSVerifier struct {
+	jwksURL      string
+	refreshEvery time.Duration
+	httpClient   *http.Client
+
+	mu   sync.RWMutex
+	keys map[string]*rsaPublicKeyHolder
+}
+
+// NewJWKSVerifier creates a verifier that fetches jwksURL immediately and
+// again every refreshEvery, until ctx is cancelled.
+func NewJWKSVerifier(ctx context.Context, jwksURL string, refreshEvery time.Duration) (*JWKSVerifier, error) {
+	if refreshEvery <= 0 {
+		refreshEvery = 10 * time.Minute
+	}
+	v := &JWKSVerifier{
+		jwksURL:      jwksURL,
+		refreshEvery: refreshEvery,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		keys:         make(map[string]*rsaPublicKeyHolder),
+	}
+	if err := v.refresh(ctx); err != nil {
+		return nil, err
+	}
+	go v.refreshLoop(ctx)
+	return v, nil
+}
+
+func (v *JWKSVerifier) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(v.refreshEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = v.refresh(ctx)
+		}
+	}
+}
+
+func (v *JWKSVerifier) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build JWKS request: %v", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var doc jwksDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %v", err)
+	}
+
+	keys := make(map[string]*rsaPublicKeyHolder, len(doc.Keys))
+	for _, key := range doc.Keys {
+		if key.Kty != "RSA" {
+			continue
+		}
+		pub, err := jwkToRSAPublicKey(key)
+		if err != nil {
+			continue
+		}
+		keys[key.Kid] = pub
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.mu.Unlock()
+	return nil
+}
+
+// Verify implements Verifier.
+func (v *JWKSVerifier) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method.Alg() != "RS256" {
+			return nil, fmt.Errorf("unexpected signing method %q", t.Method.Alg())
+		}
+		kid, _ := t.Header["kid"].(string)
+
+		v.mu.RLock()
+		key, ok := v.keys[kid]
+		v.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key.key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("token is not valid")
+	}
+	return claims, nil
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+)
+
+// rsaPublicKeyHolder just wraps the parsed key; kept as its own type in
+// case per-key metadata (e.g. expiry) is needed later.
+type rsaPublicKeyHolder struct {
+	key *rsa.PublicKey
+}
+
+// jwkToRSAPublicKey decodes a JWKS RSA key's base64url-encoded modulus (n)
+// and exponent (e) into an *rsa.PublicKey.
+func jwkToRSAPublicKey(k jwk) (*rsaPublicKeyHolder, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %v", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %v", err)
+	}
+
+	n := new(big.Int).SetBytes(nBytes)
+	e := new(big.Int).SetBytes(eBytes)
+
+	return &rsaPublicKeyHolder{key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+}
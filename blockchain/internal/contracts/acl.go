@@ -0,0 +1,105 @@
+package contracts
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/yourusername/smart-contract-intelligence/internal/auth"
+)
+
+// AddCollaborator grants a role on a contract to another user. Only the
+// owner may manage collaborators.
+func (s *Service) AddCollaborator(c *gin.Context) {
+	id := c.Param("id")
+	userID, _ := auth.UserID(c)
+
+	var req struct {
+		UserID string `json:"user_id" binding:"required"`
+		Role   string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	switch req.Role {
+	case RoleViewer, RoleEditor, RoleDeployer:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role must be one of viewer, editor, deployer"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var contract Contract
+	if err := s.contracts.FindOne(ctx, bson.M{"_id": id}).Decode(&contract); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Contract not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve contract"})
+		return
+	}
+	if contract.OwnerID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the owner may manage collaborators"})
+		return
+	}
+
+	_, err := s.contracts.UpdateOne(ctx,
+		bson.M{"_id": id, "collaborators.user_id": bson.M{"$ne": req.UserID}},
+		bson.M{"$push": bson.M{"collaborators": Collaborator{UserID: req.UserID, Role: req.Role}}},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add collaborator"})
+		return
+	}
+
+	// If the collaborator already existed, update their role instead.
+	_, _ = s.contracts.UpdateOne(ctx,
+		bson.M{"_id": id, "collaborators.user_id": req.UserID},
+		bson.M{"$set": bson.M{"collaborators.$.role": req.Role}},
+	)
+
+	c.JSON(http.StatusOK, gin.H{"message": "collaborator added"})
+}
+
+// RemoveCollaborator revokes a collaborator's access. Only the owner may
+// manage collaborators.
+func (s *Service) RemoveCollaborator(c *gin.Context) {
+	id := c.Param("id")
+	collaboratorID := c.Param("userId")
+	userID, _ := auth.UserID(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var contract Contract
+	if err := s.contracts.FindOne(ctx, bson.M{"_id": id}).Decode(&contract); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Contract not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve contract"})
+		return
+	}
+	if contract.OwnerID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the owner may manage collaborators"})
+		return
+	}
+
+	_, err := s.contracts.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$pull": bson.M{"collaborators": bson.M{"user_id": collaboratorID}}},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove collaborator"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "collaborator removed"})
+}
@@ -0,0 +1,329 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/yourusername/smart-contract-intelligence/internal/auth"
+	"github.com/yourusername/smart-contract-intelligence/internal/eth"
+	"github.com/yourusername/smart-contract-intelligence/pkg/types"
+)
+
+// templatePlaceholder matches {{contracts.<id>.address}} references in
+// constructor argument JSON so they can be substituted with a predicted
+// CREATE address before the bundle is submitted.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*contracts\.([^.\s]+)\.address\s*\}\}`)
+
+// BundleDeployRequest describes an ordered set of contracts to deploy as a
+// single all-or-nothing bundle from one deployer account.
+type BundleDeployRequest struct {
+	DeployerID  string            `json:"deployer_id"`
+	ChainID     int               `json:"chain_id,omitempty"`
+	GasLimit    uint64            `json:"gas_limit,omitempty"`
+	ContractIDs []string          `json:"contract_ids"`
+	Constructors map[string]string `json:"constructors,omitempty"` // contract_id -> constructor args JSON template
+}
+
+// BundleContractResult tracks the outcome of one leg of a bundle deployment.
+type BundleContractResult struct {
+	ContractID       string `bson:"contract_id" json:"contract_id"`
+	PredictedAddress string `bson:"predicted_address" json:"predicted_address"`
+	Nonce            uint64 `bson:"nonce" json:"nonce"`
+	TxHash           string `bson:"tx_hash,omitempty" json:"tx_hash,omitempty"`
+	Status           string `bson:"status" json:"status"` // "pending", "confirmed", "failed", "skipped"
+	Error            string `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+// DeploymentBundle is the Mongo-persisted record of a bundle deployment.
+type DeploymentBundle struct {
+	ID         string                  `bson:"_id" json:"id"`
+	DeployerID string                  `bson:"deployer_id" json:"deployer_id"`
+	ChainID    int                     `bson:"chain_id" json:"chain_id"`
+	Status     string                  `bson:"status" json:"status"` // "pending", "succeeded", "failed"
+	Contracts  []BundleContractResult  `bson:"contracts" json:"contracts"`
+	CreatedAt  time.Time               `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time               `bson:"updated_at" json:"updated_at"`
+}
+
+// SetBundleCollection wires the Mongo collection used to persist deployment
+// bundles. Must be called before DeployBundle/GetBundleStatus are used.
+func (s *Service) SetBundleCollection(bundles *mongo.Collection) {
+	s.bundles = bundles
+}
+
+// resolveEthClient returns the eth.Client to use for chainID, preferring a
+// pooled client from the ChainRegistry and falling back to the service's
+// default client for chainID 0 or when no registry is configured.
+func (s *Service) resolveEthClient(chainID int) (*eth.Client, error) {
+	if chainID == 0 || s.chainRegistry == nil {
+		if s.ethClient == nil {
+			return nil, fmt.Errorf("no eth client configured")
+		}
+		return s.ethClient, nil
+	}
+	return s.ethClientForChain(chainID)
+}
+
+// DeployBundle deploys an ordered list of contracts as a single logical
+// unit: addresses are predicted up front via CREATE nonce math, constructor
+// argument templates referencing {{contracts.<id>.address}} are resolved
+// against those predictions, and every transaction is submitted with
+// sequential nonces from the same deployer. The bundle is only marked
+// "succeeded" once every receipt confirms; the first failure halts
+// submission and marks the whole bundle "failed".
+func (s *Service) DeployBundle(c *gin.Context) {
+	if s.bundles == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "bundle deployment is not configured"})
+		return
+	}
+
+	var req BundleDeployRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if userID, ok := auth.UserID(c); ok {
+		req.DeployerID = userID
+	}
+	if len(req.ContractIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "contract_ids must not be empty"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	contractDocs := make([]Contract, len(req.ContractIDs))
+	for i, id := range req.ContractIDs {
+		var contract Contract
+		if err := s.contracts.FindOne(ctx, bson.M{"_id": id}).Decode(&contract); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("contract %s not found", id)})
+			return
+		}
+		if !contract.CanDeploy(req.DeployerID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("not permitted to deploy contract %s", id)})
+			return
+		}
+		contractDocs[i] = contract
+	}
+
+	client, err := s.resolveEthClient(req.ChainID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	deployer, err := client.DeployerAddress()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to resolve deployer address: %v", err)})
+		return
+	}
+
+	startNonce, err := client.NextNonce(ctx, deployer)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	gasLimit := req.GasLimit
+	if gasLimit == 0 {
+		gasLimit = 4000000
+	}
+
+	// Precompute every contract's address and nonce before submitting
+	// anything, so constructor templates can reference addresses that
+	// haven't been deployed yet.
+	predicted := make(map[string]common.Address, len(req.ContractIDs))
+	results := make([]BundleContractResult, len(req.ContractIDs))
+	for i, id := range req.ContractIDs {
+		nonce := startNonce + uint64(i)
+		addr := eth.PredictContractAddress(deployer, nonce)
+		predicted[id] = addr
+		results[i] = BundleContractResult{
+			ContractID:       id,
+			PredictedAddress: addr.Hex(),
+			Nonce:            nonce,
+			Status:           "pending",
+		}
+	}
+
+	bundle := &DeploymentBundle{
+		ID:         uuid.New().String(),
+		DeployerID: req.DeployerID,
+		ChainID:    req.ChainID,
+		Status:     "pending",
+		Contracts:  results,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if _, err := s.bundles.InsertOne(ctx, bundle); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record bundle"})
+		return
+	}
+
+	// Submit every transaction with its sequential nonce in one flush before
+	// waiting on any of them: sending a leg and then blocking on its receipt
+	// before the next leg is even submitted would serialize the whole
+	// bundle's wall-clock time, multiplying it by len(contractDocs) and
+	// risking the per-request context timeout on anything but the smallest
+	// bundles.
+	bundleFailed := false
+	sentAt := make([]int, 0, len(contractDocs)) // indices into results/contractDocs that were sent
+	for i, contract := range contractDocs {
+		if bundleFailed {
+			results[i].Status = "skipped"
+			continue
+		}
+
+		constructorArgs := req.Constructors[req.ContractIDs[i]]
+		constructorArgs = substituteAddressTemplates(constructorArgs, predicted)
+
+		nonce := results[i].Nonce
+		sendCtx, sendCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		sent, sendErr := client.SendDeployment(sendCtx, &types.DeploymentRequest{
+			ContractCode:         contract.ContractCode,
+			ConstructorArguments: constructorArgs,
+			ChainID:              req.ChainID,
+			GasLimit:             gasLimit,
+			Nonce:                &nonce,
+		})
+		sendCancel()
+
+		if sendErr != nil {
+			results[i].Status = "failed"
+			results[i].Error = sendErr.Error()
+			bundleFailed = true
+			continue
+		}
+
+		results[i].Status = "pending"
+		results[i].TxHash = sent.TxHash
+		sentAt = append(sentAt, i)
+	}
+
+	// Now wait for every submitted leg to be mined, concurrently rather than
+	// one at a time, since they're all already in flight.
+	var wg sync.WaitGroup
+	for _, i := range sentAt {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			waitCtx, waitCancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer waitCancel()
+
+			deployResult, err := client.WaitDeployed(waitCtx, results[i].TxHash, eth.WaitOptions{Confirmations: eth.Confirmations(1)})
+			if err != nil {
+				results[i].Status = "failed"
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Status = "confirmed"
+			results[i].TxHash = deployResult.TxHash
+		}(i)
+	}
+	wg.Wait()
+
+	for _, i := range sentAt {
+		if results[i].Status != "confirmed" {
+			bundleFailed = true
+			break
+		}
+	}
+
+	finalStatus := "succeeded"
+	if bundleFailed {
+		finalStatus = "failed"
+	}
+	bundle.Status = finalStatus
+	bundle.Contracts = results
+	bundle.UpdatedAt = time.Now()
+
+	updateCtx, updateCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer updateCancel()
+	if _, err := s.bundles.UpdateOne(updateCtx, bson.M{"_id": bundle.ID}, bson.M{"$set": bson.M{
+		"status":     bundle.Status,
+		"contracts":  bundle.Contracts,
+		"updated_at": bundle.UpdatedAt,
+	}}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":  "bundle deployment finished but failed to persist final status",
+			"bundle": bundle,
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if finalStatus == "failed" {
+		status = http.StatusInternalServerError
+	}
+	c.JSON(status, bundle)
+}
+
+// GetBundleStatus retrieves a previously submitted deployment bundle.
+func (s *Service) GetBundleStatus(c *gin.Context) {
+	if s.bundles == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "bundle deployment is not configured"})
+		return
+	}
+
+	id := c.Param("id")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var bundle DeploymentBundle
+	if err := s.bundles.FindOne(ctx, bson.M{"_id": id}).Decode(&bundle); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "bundle not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve bundle"})
+		return
+	}
+
+	requestUserID, _ := auth.UserID(c)
+	if requestUserID != bundle.DeployerID {
+		for _, contractResult := range bundle.Contracts {
+			var contract Contract
+			if err := s.contracts.FindOne(ctx, bson.M{"_id": contractResult.ContractID}).Decode(&contract); err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": "bundle references a contract that no longer exists"})
+				return
+			}
+			if !contract.CanRead(requestUserID) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view this bundle"})
+				return
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
+// substituteAddressTemplates replaces {{contracts.<id>.address}} references
+// in a constructor argument template with the predicted address for that
+// contract ID.
+func substituteAddressTemplates(raw string, predicted map[string]common.Address) string {
+	if raw == "" {
+		return raw
+	}
+	return templatePlaceholder.ReplaceAllStringFunc(raw, func(match string) string {
+		sub := templatePlaceholder.FindStringSubmatch(match)
+		if len(sub) != 2 {
+			return match
+		}
+		addr, ok := predicted[sub[1]]
+		if !ok {
+			return match
+		}
+		return addr.Hex()
+	})
+}
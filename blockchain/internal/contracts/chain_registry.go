@@ -0,0 +1,80 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// GasStrategy describes how transaction fees are priced on a chain.
+type GasStrategy string
+
+const (
+	GasStrategyLegacy  GasStrategy = "legacy"
+	GasStrategyEIP1559 GasStrategy = "eip1559"
+)
+
+// ChainConfig describes everything the deployment pipeline needs to know
+// about a single target chain.
+type ChainConfig struct {
+	ChainID            int         `json:"chain_id"`
+	Name               string      `json:"name"`
+	RPCURL             string      `json:"rpc_url"`
+	NativeSymbol       string      `json:"native_symbol"`
+	GasStrategy        GasStrategy `json:"gas_strategy"`
+	ConfirmationDepth  uint64      `json:"confirmation_depth"`
+	MaxFeePerGas       int64       `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas int64     `json:"max_priority_fee_per_gas,omitempty"`
+}
+
+// ChainRegistry holds the set of chains the service is willing to deploy to.
+type ChainRegistry struct {
+	chains map[int]ChainConfig
+}
+
+// NewChainRegistry builds a registry from an already-decoded list of chains.
+func NewChainRegistry(chains []ChainConfig) *ChainRegistry {
+	r := &ChainRegistry{chains: make(map[int]ChainConfig, len(chains))}
+	for _, c := range chains {
+		r.chains[c.ChainID] = c
+	}
+	return r
+}
+
+// LoadChainRegistry reads chain configuration from a JSON file, falling back
+// to the CHAIN_REGISTRY_PATH environment variable when path is empty.
+func LoadChainRegistry(path string) (*ChainRegistry, error) {
+	if path == "" {
+		path = os.Getenv("CHAIN_REGISTRY_PATH")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("chain registry path is required")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain registry config: %v", err)
+	}
+
+	var chains []ChainConfig
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("failed to parse chain registry config: %v", err)
+	}
+
+	return NewChainRegistry(chains), nil
+}
+
+// Get returns the configuration for a chain ID, if known.
+func (r *ChainRegistry) Get(chainID int) (ChainConfig, bool) {
+	cfg, ok := r.chains[chainID]
+	return cfg, ok
+}
+
+// All returns every registered chain configuration.
+func (r *ChainRegistry) All() []ChainConfig {
+	out := make([]ChainConfig, 0, len(r.chains))
+	for _, c := range r.chains {
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,345 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexerCheckpoint tracks the last block successfully indexed for a
+// (contract, chain) pair so restarts resume without re-scanning history.
+type indexerCheckpoint struct {
+	ID        string `bson:"_id"`
+	LastBlock uint64 `bson:"last_block"`
+}
+
+func checkpointID(contractID string, chainID int) string {
+	return fmt.Sprintf("%s:%d", contractID, chainID)
+}
+
+// EventIndexer polls deployed contracts for new logs, decodes them against
+// the contract's stored ABI, persists them to the events collection, and
+// fans them out to any live StreamEvents listeners.
+type EventIndexer struct {
+	service      *Service
+	pollInterval time.Duration
+
+	mu            sync.Mutex
+	subscriptions map[string][]EventSubscription  // contract ID -> subscriptions
+	listeners     map[string][]chan ContractEvent // subscription ID -> listeners
+}
+
+// NewEventIndexer creates an indexer bound to a Service's contracts, eth
+// clients, and events/checkpoint collections.
+func NewEventIndexer(service *Service, pollInterval time.Duration) *EventIndexer {
+	if pollInterval <= 0 {
+		pollInterval = 15 * time.Second
+	}
+	return &EventIndexer{
+		service:       service,
+		pollInterval:  pollInterval,
+		subscriptions: make(map[string][]EventSubscription),
+		listeners:     make(map[string][]chan ContractEvent),
+	}
+}
+
+// Register records a subscription so the poll loop knows to decode and
+// broadcast matching events for that contract.
+func (idx *EventIndexer) Register(sub EventSubscription) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.subscriptions[sub.ContractID] = append(idx.subscriptions[sub.ContractID], sub)
+}
+
+// Subscription looks up a previously-registered subscription by ID, so a
+// caller that only has a subscription ID (e.g. StreamEvents) can recover
+// which contract it belongs to for an access check.
+func (idx *EventIndexer) Subscription(subscriptionID string) (EventSubscription, bool) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, subs := range idx.subscriptions {
+		for _, sub := range subs {
+			if sub.ID == subscriptionID {
+				return sub, true
+			}
+		}
+	}
+	return EventSubscription{}, false
+}
+
+// Listen returns a channel that receives decoded events matching
+// subscriptionID until Unlisten is called.
+func (idx *EventIndexer) Listen(subscriptionID string) chan ContractEvent {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	ch := make(chan ContractEvent, 32)
+	idx.listeners[subscriptionID] = append(idx.listeners[subscriptionID], ch)
+	return ch
+}
+
+// Unlisten removes and closes a channel previously returned by Listen.
+func (idx *EventIndexer) Unlisten(subscriptionID string, ch chan ContractEvent) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	chans := idx.listeners[subscriptionID]
+	for i, existing := range chans {
+		if existing == ch {
+			idx.listeners[subscriptionID] = append(chans[:i], chans[i+1:]...)
+			close(ch)
+			return
+		}
+	}
+}
+
+// Run polls every subscribed contract on pollInterval until ctx is
+// cancelled. Intended to be launched once as a background goroutine.
+func (idx *EventIndexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(idx.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			idx.pollOnce(ctx)
+		}
+	}
+}
+
+func (idx *EventIndexer) pollOnce(ctx context.Context) {
+	idx.mu.Lock()
+	contractIDs := make([]string, 0, len(idx.subscriptions))
+	for id := range idx.subscriptions {
+		contractIDs = append(contractIDs, id)
+	}
+	idx.mu.Unlock()
+
+	for _, contractID := range contractIDs {
+		if err := idx.pollContract(ctx, contractID); err != nil {
+			log.Printf("event indexer: contract %s: %v", contractID, err)
+		}
+	}
+}
+
+// reorgScanDepth is how many already-indexed blocks pollContract re-fetches
+// on every pass, behind the checkpoint, so a reorg that replaces one of
+// them is caught. Standard eth_getLogs only ever returns removed:true
+// entries via a live eth_subscribeLogs push, never on a plain historical
+// range query, so detecting a reorg here means re-querying the recent
+// range and diffing against what's already persisted rather than trusting
+// a removed flag from the RPC response.
+const reorgScanDepth = 12
+
+// pollContract fetches logs from reorgScanDepth blocks behind the stored
+// checkpoint onward, decodes and persists any that are new, advances the
+// checkpoint, broadcasts to matching subscribers, and reconciles that
+// re-scanned window against the events collection: any previously
+// persisted event no longer present in the fresh fetch is marked
+// removed=true, since the block that produced it was reorged out.
+func (idx *EventIndexer) pollContract(ctx context.Context, contractID string) error {
+	var contract Contract
+	if err := idx.service.contracts.FindOne(ctx, bson.M{"_id": contractID}).Decode(&contract); err != nil {
+		return fmt.Errorf("failed to load contract: %v", err)
+	}
+	if contract.DeployedAddress == "" {
+		return nil
+	}
+
+	parsedABI, err := contractABI(&contract)
+	if err != nil {
+		return err
+	}
+
+	const chainID = 0 // single default chain until multi-chain deployments carry per-chain indexers
+	client, err := idx.service.resolveEthClient(chainID)
+	if err != nil {
+		return err
+	}
+
+	checkpoint := idx.loadCheckpoint(ctx, contractID, chainID)
+	fromBlock := int64(checkpoint.LastBlock) + 1 - reorgScanDepth
+	if fromBlock < 0 {
+		fromBlock = 0
+	}
+
+	logs, err := client.FilterLogs(ctx, common.HexToAddress(contract.DeployedAddress), nil, big.NewInt(fromBlock), nil)
+	if err != nil {
+		return fmt.Errorf("failed to filter logs: %v", err)
+	}
+
+	idx.mu.Lock()
+	subs := append([]EventSubscription(nil), idx.subscriptions[contractID]...)
+	idx.mu.Unlock()
+
+	idx.reconcileReorgs(ctx, contractID, chainID, uint64(fromBlock), logs, subs)
+
+	highestBlock := checkpoint.LastBlock
+	for _, logEntry := range logs {
+		if len(logEntry.Topics) == 0 {
+			continue
+		}
+		event, ok := eventByTopic(parsedABI, logEntry.Topics[0])
+		if !ok {
+			continue
+		}
+		args, err := decodeEventArgs(event, logEntry)
+		if err != nil {
+			continue
+		}
+
+		decoded := ContractEvent{
+			ID:          fmt.Sprintf("%s-%d", logEntry.TxHash.Hex(), logEntry.Index),
+			ContractID:  contractID,
+			ChainID:     chainID,
+			EventName:   event.Name,
+			BlockNumber: logEntry.BlockNumber,
+			TxHash:      logEntry.TxHash.Hex(),
+			LogIndex:    logEntry.Index,
+			Args:        args,
+			Removed:     logEntry.Removed,
+			IndexedAt:   time.Now(),
+		}
+		idx.persist(ctx, decoded)
+
+		for _, sub := range subs {
+			if sub.EventName == decoded.EventName {
+				idx.broadcast(sub.ID, decoded)
+			}
+		}
+
+		if logEntry.BlockNumber > highestBlock {
+			highestBlock = logEntry.BlockNumber
+		}
+	}
+
+	if highestBlock > checkpoint.LastBlock {
+		idx.saveCheckpoint(ctx, contractID, chainID, highestBlock)
+	}
+	return nil
+}
+
+// reconcileReorgs marks as removed any event already persisted in
+// [fromBlock, latest] for (contractID, chainID) that isn't present in
+// fresh, the logs a fresh eth_getLogs call over that same range just
+// returned. Its absence means the block that produced it is no longer part
+// of the canonical chain.
+func (idx *EventIndexer) reconcileReorgs(ctx context.Context, contractID string, chainID int, fromBlock uint64, fresh []ethtypes.Log, subs []EventSubscription) {
+	if idx.service.events == nil {
+		return
+	}
+
+	freshIDs := make(map[string]bool, len(fresh))
+	for _, logEntry := range fresh {
+		freshIDs[fmt.Sprintf("%s-%d", logEntry.TxHash.Hex(), logEntry.Index)] = true
+	}
+
+	cursor, err := idx.service.events.Find(ctx, bson.M{
+		"contract_id":  contractID,
+		"chain_id":     chainID,
+		"block_number": bson.M{"$gte": fromBlock},
+		"removed":      false,
+	})
+	if err != nil {
+		log.Printf("event indexer: failed to query events for reorg check on %s: %v", contractID, err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var persisted []ContractEvent
+	if err := cursor.All(ctx, &persisted); err != nil {
+		log.Printf("event indexer: failed to decode events for reorg check on %s: %v", contractID, err)
+		return
+	}
+
+	for _, event := range persisted {
+		if freshIDs[event.ID] {
+			continue
+		}
+		if _, err := idx.service.events.UpdateOne(ctx,
+			bson.M{"_id": event.ID},
+			bson.M{"$set": bson.M{"removed": true}},
+		); err != nil {
+			log.Printf("event indexer: failed to mark event %s removed: %v", event.ID, err)
+			continue
+		}
+		event.Removed = true
+		for _, sub := range subs {
+			if sub.EventName == event.EventName {
+				idx.broadcast(sub.ID, event)
+			}
+		}
+	}
+}
+
+func (idx *EventIndexer) persist(ctx context.Context, event ContractEvent) {
+	if idx.service.events == nil {
+		return
+	}
+	upsert := true
+	_, err := idx.service.events.UpdateOne(ctx,
+		bson.M{"_id": event.ID},
+		bson.M{"$set": event},
+		&options.UpdateOptions{Upsert: &upsert},
+	)
+	if err != nil {
+		log.Printf("event indexer: failed to persist event %s: %v", event.ID, err)
+	}
+}
+
+func (idx *EventIndexer) broadcast(subscriptionID string, event ContractEvent) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, ch := range idx.listeners[subscriptionID] {
+		select {
+		case ch <- event:
+		default:
+			// Slow consumer; drop rather than block the indexer loop.
+		}
+	}
+}
+
+func (idx *EventIndexer) loadCheckpoint(ctx context.Context, contractID string, chainID int) indexerCheckpoint {
+	empty := indexerCheckpoint{ID: checkpointID(contractID, chainID)}
+	if idx.service.checkpoints == nil {
+		return empty
+	}
+	var cp indexerCheckpoint
+	if err := idx.service.checkpoints.FindOne(ctx, bson.M{"_id": empty.ID}).Decode(&cp); err != nil {
+		return empty
+	}
+	return cp
+}
+
+func (idx *EventIndexer) saveCheckpoint(ctx context.Context, contractID string, chainID int, lastBlock uint64) {
+	if idx.service.checkpoints == nil {
+		return
+	}
+	upsert := true
+	_, err := idx.service.checkpoints.UpdateOne(ctx,
+		bson.M{"_id": checkpointID(contractID, chainID)},
+		bson.M{"$set": bson.M{"last_block": lastBlock}},
+		&options.UpdateOptions{Upsert: &upsert},
+	)
+	if err != nil {
+		log.Printf("event indexer: failed to save checkpoint for %s: %v", contractID, err)
+	}
+}
+
+func eventByTopic(parsedABI abi.ABI, topic common.Hash) (abi.Event, bool) {
+	for _, event := range parsedABI.Events {
+		if event.ID == topic {
+			return event, true
+		}
+	}
+	return abi.Event{}, false
+}
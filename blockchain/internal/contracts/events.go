@@ -0,0 +1,465 @@
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/yourusername/smart-contract-intelligence/internal/auth"
+	"github.com/yourusername/smart-contract-intelligence/internal/eth"
+)
+
+// ContractEvent is a decoded contract log, persisted for historical queries
+// and pushed to live subscribers.
+type ContractEvent struct {
+	ID          string                 `bson:"_id" json:"id"`
+	ContractID  string                 `bson:"contract_id" json:"contract_id"`
+	ChainID     int                    `bson:"chain_id" json:"chain_id"`
+	EventName   string                 `bson:"event_name" json:"event_name"`
+	BlockNumber uint64                 `bson:"block_number" json:"block_number"`
+	TxHash      string                 `bson:"tx_hash" json:"tx_hash"`
+	LogIndex    uint                   `bson:"log_index" json:"log_index"`
+	Args        map[string]interface{} `bson:"args" json:"args"`
+	Removed     bool                   `bson:"removed" json:"removed"`
+	IndexedAt   time.Time              `bson:"indexed_at" json:"indexed_at"`
+}
+
+// EventSubscription describes one registered interest in a contract's
+// events, as created by SubscribeEvents and consumed by StreamEvents.
+type EventSubscription struct {
+	ID         string            `json:"id"`
+	ContractID string            `json:"contract_id"`
+	EventName  string            `json:"event_name"`
+	Indexed    map[string]string `json:"indexed,omitempty"` // argument name -> hex value; stored only, the pushed feed is filtered by EventName alone
+}
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// SetEventsCollection wires the Mongo collection used to persist decoded
+// contract events and ensures its query index exists.
+func (s *Service) SetEventsCollection(events *mongo.Collection) {
+	s.events = events
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, _ = events.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "contract_id", Value: 1}, {Key: "event_name", Value: 1}, {Key: "block_number", Value: 1}},
+	})
+}
+
+// SetCheckpointCollection wires the Mongo collection used to track how far
+// the EventIndexer has scanned each (contract, chain) pair.
+func (s *Service) SetCheckpointCollection(checkpoints *mongo.Collection) {
+	s.checkpoints = checkpoints
+}
+
+// SetIndexer attaches the background EventIndexer used to service
+// SubscribeEvents and StreamEvents.
+func (s *Service) SetIndexer(indexer *EventIndexer) {
+	s.indexer = indexer
+}
+
+// contractABI parses the ABI stored on a contract's metadata.
+func contractABI(contract *Contract) (abi.ABI, error) {
+	raw, ok := contract.Metadata["abi"]
+	if !ok {
+		return abi.ABI{}, fmt.Errorf("contract has no ABI in metadata")
+	}
+	abiJSON, err := json.Marshal(raw)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to marshal stored ABI: %v", err)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(abiJSON)))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse contract ABI: %v", err)
+	}
+	return parsed, nil
+}
+
+// SubscribeEvents registers interest in one of a contract's decoded events,
+// generated from its stored ABI. The resulting subscription ID is used by
+// StreamEvents to filter the pushed feed.
+func (s *Service) SubscribeEvents(c *gin.Context) {
+	contractID := c.Param("id")
+
+	var req struct {
+		EventName string            `json:"event_name" binding:"required"`
+		Indexed   map[string]string `json:"indexed"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var contract Contract
+	if err := s.contracts.FindOne(ctx, bson.M{"_id": contractID}).Decode(&contract); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contract not found"})
+		return
+	}
+
+	requestUserID, _ := auth.UserID(c)
+	if !contract.CanRead(requestUserID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view this contract"})
+		return
+	}
+
+	parsedABI, err := contractABI(&contract)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, ok := parsedABI.Events[req.EventName]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("event %s not found in ABI", req.EventName)})
+		return
+	}
+	if s.indexer == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "event indexing is not configured"})
+		return
+	}
+
+	sub := EventSubscription{
+		ID:         uuid.New().String(),
+		ContractID: contractID,
+		EventName:  req.EventName,
+		Indexed:    req.Indexed,
+	}
+	s.indexer.Register(sub)
+
+	c.JSON(http.StatusCreated, sub)
+}
+
+// GetContractEvents returns decoded historical events for a contract,
+// querying on-chain logs directly via eth_getLogs with topic filtering on
+// indexed arguments (indexed.<argName>=0x...).
+func (s *Service) GetContractEvents(c *gin.Context) {
+	contractID := c.Param("id")
+	eventName := c.Query("name")
+	if eventName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name query parameter is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var contract Contract
+	if err := s.contracts.FindOne(ctx, bson.M{"_id": contractID}).Decode(&contract); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contract not found"})
+		return
+	}
+	if contract.DeployedAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "contract has not been deployed"})
+		return
+	}
+
+	requestUserID, _ := auth.UserID(c)
+	if !contract.CanRead(requestUserID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view this contract"})
+		return
+	}
+
+	parsedABI, err := contractABI(&contract)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	eventAbi, ok := parsedABI.Events[eventName]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("event %s not found in ABI", eventName)})
+		return
+	}
+
+	topics := [][]common.Hash{{eventAbi.ID}}
+	topics = append(topics, indexedArgTopics(eventAbi, c.Request.URL.Query())...)
+
+	client, err := s.resolveEthClient(0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	logs, err := client.FilterLogs(
+		ctx,
+		common.HexToAddress(contract.DeployedAddress),
+		topics,
+		parseBlockParam(c.Query("fromBlock")),
+		parseBlockParam(c.Query("toBlock")),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	events := make([]ContractEvent, 0, len(logs))
+	for _, logEntry := range logs {
+		args, err := decodeEventArgs(eventAbi, logEntry)
+		if err != nil {
+			continue
+		}
+		events = append(events, ContractEvent{
+			ContractID:  contractID,
+			EventName:   eventName,
+			BlockNumber: logEntry.BlockNumber,
+			TxHash:      logEntry.TxHash.Hex(),
+			LogIndex:    logEntry.Index,
+			Args:        args,
+			Removed:     logEntry.Removed,
+		})
+	}
+
+	offset, limit := parsePagination(c.Query("offset"), c.Query("limit"))
+	if offset > len(events) {
+		offset = len(events)
+	}
+	end := offset + limit
+	if end > len(events) {
+		end = len(events)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events[offset:end], "total": len(events)})
+}
+
+// parsePagination parses offset/limit query params, defaulting to a
+// 0-offset, 100-result page and ignoring invalid values.
+func parsePagination(rawOffset, rawLimit string) (offset, limit int) {
+	limit = 100
+	if n, err := strconv.Atoi(rawLimit); err == nil && n > 0 {
+		limit = n
+	}
+	if n, err := strconv.Atoi(rawOffset); err == nil && n >= 0 {
+		offset = n
+	}
+	return offset, limit
+}
+
+// StreamEvents upgrades to a websocket and pushes decoded events matching a
+// previously-registered subscription (?subscription_id=...) as JSON.
+func (s *Service) StreamEvents(c *gin.Context) {
+	subscriptionID := c.Query("subscription_id")
+	if subscriptionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "subscription_id query parameter is required"})
+		return
+	}
+	if s.indexer == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "event indexing is not configured"})
+		return
+	}
+
+	sub, ok := s.indexer.Subscription(subscriptionID)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "subscription not found"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var contract Contract
+	if err := s.contracts.FindOne(ctx, bson.M{"_id": sub.ContractID}).Decode(&contract); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contract not found"})
+		return
+	}
+	requestUserID, _ := auth.UserID(c)
+	if !contract.CanRead(requestUserID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view this contract"})
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ch := s.indexer.Listen(subscriptionID)
+	defer s.indexer.Unlisten(subscriptionID, ch)
+
+	for event := range ch {
+		if err := conn.WriteJSON(event); err != nil {
+			return
+		}
+	}
+}
+
+// StreamEventsLive upgrades to a websocket and pushes decoded events for a
+// contract/event pair straight from an eth.EventSubscriber, bypassing the
+// EventIndexer's polling cadence. The subscriber prefers a live
+// SubscribeFilterLogs push over a ws://wss:// RPC endpoint, reconnecting
+// with exponential backoff if it drops, and falls back to polling
+// FilterLogs when only an HTTP endpoint is configured.
+func (s *Service) StreamEventsLive(c *gin.Context) {
+	contractID := c.Param("id")
+	eventName := c.Query("name")
+	if eventName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name query parameter is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var contract Contract
+	if err := s.contracts.FindOne(ctx, bson.M{"_id": contractID}).Decode(&contract); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "contract not found"})
+		return
+	}
+	if contract.DeployedAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "contract has not been deployed"})
+		return
+	}
+
+	requestUserID, _ := auth.UserID(c)
+	if !contract.CanRead(requestUserID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view this contract"})
+		return
+	}
+
+	parsedABI, err := contractABI(&contract)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	eventAbi, ok := parsedABI.Events[eventName]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("event %s not found in ABI", eventName)})
+		return
+	}
+
+	client, err := s.resolveEthClient(0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	streamCtx, cancelStream := context.WithCancel(context.Background())
+	defer cancelStream()
+
+	// Detect the client going away so the subscriber stops as soon as the
+	// socket closes, rather than only on the next failed write.
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancelStream()
+				return
+			}
+		}
+	}()
+
+	subscriber := eth.NewEventSubscriber(client, ethereum.FilterQuery{
+		Addresses: []common.Address{common.HexToAddress(contract.DeployedAddress)},
+		Topics:    [][]common.Hash{{eventAbi.ID}},
+	})
+	subscriber.Run(streamCtx)
+
+	for {
+		select {
+		case <-streamCtx.Done():
+			return
+		case <-subscriber.Notify():
+			for _, logEntry := range subscriber.Drain() {
+				args, err := decodeEventArgs(eventAbi, logEntry)
+				if err != nil {
+					continue
+				}
+				event := ContractEvent{
+					ContractID:  contractID,
+					EventName:   eventName,
+					BlockNumber: logEntry.BlockNumber,
+					TxHash:      logEntry.TxHash.Hex(),
+					LogIndex:    logEntry.Index,
+					Args:        args,
+					Removed:     logEntry.Removed,
+				}
+				if err := conn.WriteJSON(event); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+func parseBlockParam(raw string) *big.Int {
+	if raw == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return big.NewInt(n)
+}
+
+// indexedArgTopics builds additional topic filters for indexed arguments
+// passed as query params in the form indexed.<argName>=0x....
+func indexedArgTopics(event abi.Event, query map[string][]string) [][]common.Hash {
+	var topics [][]common.Hash
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		values, ok := query["indexed."+input.Name]
+		if !ok || len(values) == 0 {
+			topics = append(topics, nil)
+			continue
+		}
+		hashes := make([]common.Hash, 0, len(values))
+		for _, v := range values {
+			hashes = append(hashes, common.HexToHash(v))
+		}
+		topics = append(topics, hashes)
+	}
+	return topics
+}
+
+// decodeEventArgs unpacks the non-indexed event arguments into a
+// name->value map and adds each indexed argument as its raw topic hash in
+// hex; indexed arguments are not ABI-decoded.
+func decodeEventArgs(event abi.Event, logEntry ethtypes.Log) (map[string]interface{}, error) {
+	args := make(map[string]interface{})
+
+	nonIndexed := event.Inputs.NonIndexed()
+	values, err := nonIndexed.UnpackValues(logEntry.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack event data: %v", err)
+	}
+	for i, input := range nonIndexed {
+		args[input.Name] = values[i]
+	}
+
+	topicIdx := 1 // topics[0] is the event signature
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		if topicIdx >= len(logEntry.Topics) {
+			break
+		}
+		args[input.Name] = logEntry.Topics[topicIdx].Hex()
+		topicIdx++
+	}
+
+	return args, nil
+}
@@ -3,7 +3,10 @@ package contracts
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,7 +16,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/yourusername/smart-contract-intelligence/internal/auth"
 	"github.com/yourusername/smart-contract-intelligence/internal/eth"
+	"github.com/yourusername/smart-contract-intelligence/internal/webhooks"
 	"github.com/yourusername/smart-contract-intelligence/pkg/types"
 )
 
@@ -28,6 +33,66 @@ type Contract struct {
 	IsPublic        bool                   `bson:"is_public" json:"is_public"`
 	DeployedAddress string                 `bson:"deployed_address,omitempty" json:"deployed_address,omitempty"`
 	DeploymentInfo  map[string]interface{} `bson:"deployment_info,omitempty" json:"deployment_info,omitempty"`
+	Deployments     []ChainDeployment      `bson:"deployments,omitempty" json:"deployments,omitempty"`
+	Collaborators   []Collaborator         `bson:"collaborators,omitempty" json:"collaborators,omitempty"`
+}
+
+// Collaborator grants a non-owner user a role on a contract.
+type Collaborator struct {
+	UserID string `bson:"user_id" json:"user_id"`
+	Role   string `bson:"role" json:"role"` // "viewer", "editor", or "deployer"
+}
+
+const (
+	RoleViewer   = "viewer"
+	RoleEditor   = "editor"
+	RoleDeployer = "deployer"
+)
+
+// hasRole reports whether userID has been granted any of the given roles.
+func (c *Contract) hasRole(userID string, roles ...string) bool {
+	for _, collaborator := range c.Collaborators {
+		if collaborator.UserID != userID {
+			continue
+		}
+		for _, role := range roles {
+			if collaborator.Role == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanRead reports whether userID may view the contract: its owner, any
+// collaborator, or anyone if the contract is public.
+func (c *Contract) CanRead(userID string) bool {
+	return c.IsPublic || c.OwnerID == userID || c.hasRole(userID, RoleViewer, RoleEditor, RoleDeployer)
+}
+
+// CanWrite reports whether userID may update or delete the contract: its
+// owner or an editor collaborator.
+func (c *Contract) CanWrite(userID string) bool {
+	return c.OwnerID == userID || c.hasRole(userID, RoleEditor)
+}
+
+// CanDeploy reports whether userID may deploy the contract: its owner or a
+// deployer collaborator.
+func (c *Contract) CanDeploy(userID string) bool {
+	return c.OwnerID == userID || c.hasRole(userID, RoleDeployer)
+}
+
+// ChainDeployment records the outcome of deploying a contract to a single
+// chain, as tracked on the Contract document.
+type ChainDeployment struct {
+	ChainID         int       `bson:"chain_id" json:"chain_id"`
+	Status          string    `bson:"status" json:"status"` // "success", "failed", or "orphaned_partial_bundle"
+	TxHash          string    `bson:"tx_hash,omitempty" json:"tx_hash,omitempty"`
+	ContractAddress string    `bson:"contract_address,omitempty" json:"contract_address,omitempty"`
+	GasUsed         uint64    `bson:"gas_used,omitempty" json:"gas_used,omitempty"`
+	BlockNumber     int       `bson:"block_number,omitempty" json:"block_number,omitempty"`
+	Error           string    `bson:"error,omitempty" json:"error,omitempty"`
+	DeployedAt      time.Time `bson:"deployed_at" json:"deployed_at"`
 }
 
 // DeploymentRequest represents a request to deploy a contract
@@ -35,6 +100,7 @@ type DeploymentRequest struct {
 	ContractID  string `json:"contract_id"`
 	DeployerID  string `json:"deployer_id"`
 	ChainID     int    `json:"chain_id,omitempty"`
+	ChainIDs    []int  `json:"chain_ids,omitempty"`
 	GasLimit    uint64 `json:"gas_limit,omitempty"`
 	Constructor string `json:"constructor_arguments,omitempty"`
 }
@@ -45,20 +111,96 @@ type DeploymentResponse struct {
 	ContractAddress string `json:"contract_address"`
 	Cost           float64 `json:"cost"`
 	BlockNumber    int     `json:"block_number"`
+	// Simulated is true when this deployment was run against an in-process
+	// simulated chain (mode=simulate) rather than broadcast for real; its
+	// tx hash and contract address only exist on that simulated chain.
+	Simulated bool `json:"simulated,omitempty"`
+}
+
+// MultiDeploymentResponse aggregates the outcome of fanning a single
+// deployment request out across several chains.
+type MultiDeploymentResponse struct {
+	ContractID string            `json:"contract_id"`
+	Status     string            `json:"status"` // "success", "partial", or "failed"
+	Results    []ChainDeployment `json:"results"`
 }
 
 // Service handles contract operations
 type Service struct {
-	contracts *mongo.Collection
-	ethClient *eth.Client
+	contracts     *mongo.Collection
+	ethClient     *eth.Client
+	chainRegistry *ChainRegistry
+	bundles       *mongo.Collection
+	events        *mongo.Collection
+	checkpoints   *mongo.Collection
+	indexer       *EventIndexer
+	webhooks      *webhooks.Service
+
+	ethClientsMu sync.Mutex
+	ethClients   map[int]*eth.Client
+}
+
+// SetWebhookService wires the webhook subsystem used to notify owners of
+// contract lifecycle events (contract.created, contract.updated,
+// contract.deleted, contract.deployed, deployment.confirmed,
+// deployment.failed).
+func (s *Service) SetWebhookService(webhookService *webhooks.Service) {
+	s.webhooks = webhookService
+}
+
+// emit is a nil-safe helper so emission points don't need to check whether
+// the webhook subsystem is configured.
+func (s *Service) emit(ctx context.Context, event, ownerID string, payload interface{}) {
+	if s.webhooks == nil {
+		return
+	}
+	s.webhooks.Emit(ctx, event, ownerID, payload)
 }
 
 // NewService creates a new contract service
 func NewService(contracts *mongo.Collection, ethClient *eth.Client) *Service {
 	return &Service{
-		contracts: contracts,
-		ethClient: ethClient,
+		contracts:  contracts,
+		ethClient:  ethClient,
+		ethClients: make(map[int]*eth.Client),
+	}
+}
+
+// NewServiceWithChainRegistry creates a contract service backed by a
+// ChainRegistry, enabling multi-chain deployment. ethClient continues to
+// serve as the default client for requests that don't specify a chain.
+func NewServiceWithChainRegistry(contracts *mongo.Collection, ethClient *eth.Client, registry *ChainRegistry) *Service {
+	s := NewService(contracts, ethClient)
+	s.chainRegistry = registry
+	return s
+}
+
+// ethClientForChain returns a pooled eth.Client for the given chain,
+// dialing and caching one from the ChainRegistry on first use.
+func (s *Service) ethClientForChain(chainID int) (*eth.Client, error) {
+	s.ethClientsMu.Lock()
+	defer s.ethClientsMu.Unlock()
+
+	if client, ok := s.ethClients[chainID]; ok {
+		return client, nil
+	}
+
+	if s.chainRegistry == nil {
+		return nil, fmt.Errorf("no chain registry configured; cannot resolve client for chain %d", chainID)
+	}
+
+	cfg, ok := s.chainRegistry.Get(chainID)
+	if !ok {
+		return nil, fmt.Errorf("chain %d is not registered", chainID)
+	}
+
+	client, err := eth.NewEthClient(cfg.RPCURL, s.ethClient.Signer())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to chain %d (%s): %v", chainID, cfg.Name, err)
 	}
+
+	s.ethClients[chainID] = client
+	return client, nil
 }
 
 // CreateContract handles the creation of a new smart contract
@@ -71,6 +213,7 @@ func (s *Service) CreateContract(c *gin.Context) {
 
 	// Generate UUID for the contract
 	contract.ID = uuid.New().String()
+	contract.OwnerID, _ = auth.UserID(c)
 	contract.CreatedAt = time.Now()
 	contract.UpdatedAt = time.Now()
 
@@ -84,6 +227,8 @@ func (s *Service) CreateContract(c *gin.Context) {
 		return
 	}
 
+	s.emit(ctx, webhooks.EventContractCreated, contract.OwnerID, contract)
+
 	c.JSON(http.StatusCreated, gin.H{
 		"contract_id": contract.ID,
 		"message": "Contract created successfully",
@@ -108,6 +253,12 @@ func (s *Service) GetContract(c *gin.Context) {
 		return
 	}
 
+	requestUserID, _ := auth.UserID(c)
+	if !contract.CanRead(requestUserID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view this contract"})
+		return
+	}
+
 	c.JSON(http.StatusOK, contract)
 }
 
@@ -202,9 +353,9 @@ func (s *Service) UpdateContract(c *gin.Context) {
 		return
 	}
 
-	// Verify ownership (in a real app, this would use authentication middleware)
-	requestUserID := c.GetHeader("X-User-ID") // Simplified; use proper auth in production
-	if existingContract.OwnerID != requestUserID {
+	// Verify the caller has write access: owner, or an editor collaborator
+	requestUserID, _ := auth.UserID(c)
+	if !existingContract.CanWrite(requestUserID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to update this contract"})
 		return
 	}
@@ -233,6 +384,8 @@ func (s *Service) UpdateContract(c *gin.Context) {
 		return
 	}
 
+	s.emit(ctx, webhooks.EventContractUpdated, existingContract.OwnerID, updates)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Contract updated successfully",
 		"modified_count": updateResult.ModifiedCount,
@@ -258,8 +411,9 @@ func (s *Service) DeleteContract(c *gin.Context) {
 		return
 	}
 
-	// Verify ownership (in a real app, this would use authentication middleware)
-	requestUserID := c.GetHeader("X-User-ID") // Simplified; use proper auth in production
+	// Only the owner may delete a contract; collaborators, however
+	// privileged, cannot.
+	requestUserID, _ := auth.UserID(c)
 	if existingContract.OwnerID != requestUserID {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to delete this contract"})
 		return
@@ -277,6 +431,8 @@ func (s *Service) DeleteContract(c *gin.Context) {
 		return
 	}
 
+	s.emit(ctx, webhooks.EventContractDeleted, existingContract.OwnerID, gin.H{"contract_id": id})
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Contract deleted successfully",
 	})
@@ -289,6 +445,11 @@ func (s *Service) DeployContract(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	// The verified token subject is authoritative; it overrides any
+	// deployer_id the caller put in the request body.
+	if userID, ok := auth.UserID(c); ok {
+		req.DeployerID = userID
+	}
 
 	// Retrieve the contract from the database
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -305,12 +466,26 @@ func (s *Service) DeployContract(c *gin.Context) {
 		return
 	}
 
-	// Verify ownership
-	if contract.OwnerID != req.DeployerID {
+	// Verify deploy access: owner, or a deployer collaborator
+	if !contract.CanDeploy(req.DeployerID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to deploy this contract"})
 		return
 	}
 
+	if len(req.ChainIDs) > 0 {
+		resp, err := s.deployToChains(contract, &req)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		status := http.StatusOK
+		if resp.Status == "failed" {
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, resp)
+		return
+	}
+
 	// Prepare deployment parameters
 	chainID := req.ChainID
 	if chainID == 0 {
@@ -324,6 +499,38 @@ func (s *Service) DeployContract(c *gin.Context) {
 		gasLimit = 4000000
 	}
 
+	// mode=simulate dry-runs the deployment against an in-process simulated
+	// chain instead of broadcasting it for real, so a caller can catch
+	// reverts and see the exact gas it will use before spending real ETH.
+	// Nothing is persisted or emitted for a simulated run.
+	if c.Query("mode") == "simulate" {
+		simCtx, simCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer simCancel()
+
+		simResult, err := s.ethClient.DryRunDeploy(simCtx, &types.DeploymentRequest{
+			ContractCode:         contract.ContractCode,
+			ConstructorArguments: req.Constructor,
+			ChainID:              chainID,
+			GasLimit:             gasLimit,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Simulated deployment failed",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, DeploymentResponse{
+			TxHash:          simResult.TxHash,
+			ContractAddress: simResult.ContractAddress,
+			Cost:            simResult.Cost,
+			BlockNumber:     simResult.BlockNumber,
+			Simulated:       true,
+		})
+		return
+	}
+
 	// Deploy the contract
 	deployCtx, deployCancel := context.WithTimeout(context.Background(), 2*time.Minute) // Longer timeout for deployment
 	defer deployCancel()
@@ -336,6 +543,11 @@ func (s *Service) DeployContract(c *gin.Context) {
 	})
 
 	if err != nil {
+		s.emit(ctx, webhooks.EventDeploymentFailed, contract.OwnerID, gin.H{
+			"contract_id": req.ContractID,
+			"chain_id":    chainID,
+			"error":       err.Error(),
+		})
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to deploy contract",
 			"details": err.Error(),
@@ -375,6 +587,9 @@ func (s *Service) DeployContract(c *gin.Context) {
 		return
 	}
 
+	s.emit(ctx, webhooks.EventContractDeployed, contract.OwnerID, deploymentInfo)
+	s.emit(ctx, webhooks.EventDeploymentConfirmed, contract.OwnerID, deploymentInfo)
+
 	c.JSON(http.StatusOK, DeploymentResponse{
 		TxHash:         deployResult.TxHash,
 		ContractAddress: deployResult.ContractAddress,
@@ -383,6 +598,200 @@ func (s *Service) DeployContract(c *gin.Context) {
 	})
 }
 
+// deployToChains fans a single deployment request out across every chain ID
+// in req.ChainIDs in parallel, aggregates the per-chain outcome into a
+// MultiDeploymentResponse, and persists the results on the Contract document
+// keyed by chain ID. If any chain fails while others succeed, the successful
+// deployments are kept (on-chain deployments cannot be undone) but are
+// flagged via rollbackPartialDeployment so operators can see the bundle
+// never fully succeeded.
+func (s *Service) deployToChains(contract Contract, req *DeploymentRequest) (*MultiDeploymentResponse, error) {
+	gasLimit := req.GasLimit
+	if gasLimit == 0 {
+		gasLimit = 4000000
+	}
+
+	results := make([]ChainDeployment, len(req.ChainIDs))
+	var wg sync.WaitGroup
+	for i, chainID := range req.ChainIDs {
+		wg.Add(1)
+		go func(i, chainID int) {
+			defer wg.Done()
+			results[i] = s.deployToSingleChain(contract, req, chainID, gasLimit)
+		}(i, chainID)
+	}
+	wg.Wait()
+
+	status := "success"
+	successCount := 0
+	for _, r := range results {
+		if r.Status == "success" {
+			successCount++
+		}
+	}
+	switch {
+	case successCount == 0:
+		status = "failed"
+	case successCount < len(results):
+		status = "partial"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	set := bson.M{"updated_at": time.Now()}
+	// Mirror the first successful leg onto DeployedAddress/DeploymentInfo,
+	// the fields GetContractEvents, StreamEventsLive and the EventIndexer
+	// poll loop (which feeds StreamEvents) all gate on, so a multi-chain
+	// deployment isn't permanently treated as "not deployed".
+	if primary := firstSuccessfulDeployment(results); primary != nil {
+		set["deployed_address"] = primary.ContractAddress
+		set["deployment_info"] = map[string]interface{}{
+			"tx_hash":          primary.TxHash,
+			"contract_address": primary.ContractAddress,
+			"block_number":     primary.BlockNumber,
+			"chain_id":         primary.ChainID,
+			"gas_used":         primary.GasUsed,
+			"deployed_at":      primary.DeployedAt,
+		}
+	}
+
+	_, err := s.contracts.UpdateOne(
+		ctx,
+		bson.M{"_id": req.ContractID},
+		bson.M{
+			"$push": bson.M{"deployments": bson.M{"$each": toBsonDeployments(results)}},
+			"$set":  set,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("deployments completed but failed to persist results: %v", err)
+	}
+
+	if status == "partial" {
+		s.rollbackPartialDeployment(req.ContractID, results)
+	}
+
+	for _, result := range results {
+		deploymentInfo := map[string]interface{}{
+			"contract_id":      req.ContractID,
+			"chain_id":         result.ChainID,
+			"tx_hash":          result.TxHash,
+			"contract_address": result.ContractAddress,
+			"block_number":     result.BlockNumber,
+			"gas_used":         result.GasUsed,
+			"deployed_at":      result.DeployedAt,
+		}
+		if result.Status == "success" {
+			s.emit(ctx, webhooks.EventContractDeployed, contract.OwnerID, deploymentInfo)
+			s.emit(ctx, webhooks.EventDeploymentConfirmed, contract.OwnerID, deploymentInfo)
+		} else {
+			deploymentInfo["error"] = result.Error
+			s.emit(ctx, webhooks.EventDeploymentFailed, contract.OwnerID, deploymentInfo)
+		}
+	}
+
+	return &MultiDeploymentResponse{
+		ContractID: req.ContractID,
+		Status:     status,
+		Results:    results,
+	}, nil
+}
+
+// deployToSingleChain performs one leg of a multi-chain deployment,
+// translating any failure into a ChainDeployment with Status "failed"
+// rather than aborting the whole fan-out.
+func (s *Service) deployToSingleChain(contract Contract, req *DeploymentRequest, chainID int, gasLimit uint64) ChainDeployment {
+	result := ChainDeployment{ChainID: chainID, DeployedAt: time.Now()}
+
+	client, err := s.ethClientForChain(chainID)
+	if err != nil {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	deployCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var gasStrategy types.GasStrategy
+	if s.chainRegistry != nil {
+		if cfg, ok := s.chainRegistry.Get(chainID); ok {
+			switch cfg.GasStrategy {
+			case GasStrategyEIP1559:
+				gasStrategy = types.GasStrategyEIP1559
+			case GasStrategyLegacy:
+				gasStrategy = types.GasStrategyLegacy
+			}
+		}
+	}
+
+	deployResult, err := client.DeployContract(deployCtx, &types.DeploymentRequest{
+		ContractCode:         contract.ContractCode,
+		ConstructorArguments: req.Constructor,
+		ChainID:              chainID,
+		GasLimit:             gasLimit,
+		GasStrategy:          gasStrategy,
+	})
+	if err != nil {
+		result.Status = "failed"
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = "success"
+	result.TxHash = deployResult.TxHash
+	result.ContractAddress = deployResult.ContractAddress
+	result.GasUsed = deployResult.GasUsed
+	result.BlockNumber = deployResult.BlockNumber
+	return result
+}
+
+// rollbackPartialDeployment handles the case where a multi-chain deployment
+// only partially succeeded. On-chain deployments cannot be reverted, so this
+// is a bookkeeping cleanup only: it flags the successful legs as orphaned so
+// operators know the bundle as a whole did not complete and can decide
+// whether to redeploy the failed chains or decommission the successful ones.
+func (s *Service) rollbackPartialDeployment(contractID string, results []ChainDeployment) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, r := range results {
+		if r.Status != "success" {
+			continue
+		}
+		_, err := s.contracts.UpdateOne(
+			ctx,
+			bson.M{"_id": contractID, "deployments.tx_hash": r.TxHash},
+			bson.M{"$set": bson.M{"deployments.$.status": "orphaned_partial_bundle"}},
+		)
+		if err != nil {
+			// Best-effort cleanup; the bundle status already reflects the
+			// failure and a human can reconcile the remaining chains.
+			continue
+		}
+	}
+}
+
+// firstSuccessfulDeployment returns the first ChainDeployment in results
+// whose Status is "success", in ChainIDs order, or nil if none succeeded.
+func firstSuccessfulDeployment(results []ChainDeployment) *ChainDeployment {
+	for i := range results {
+		if results[i].Status == "success" {
+			return &results[i]
+		}
+	}
+	return nil
+}
+
+func toBsonDeployments(results []ChainDeployment) []interface{} {
+	out := make([]interface{}, len(results))
+	for i, r := range results {
+		out[i] = r
+	}
+	return out
+}
+
 // GetDeploymentStatus checks the status of a deployment transaction
 func (s *Service) GetDeploymentStatus(c *gin.Context) {
 	txHash := c.Param("txHash")
@@ -404,4 +813,56 @@ func (s *Service) GetDeploymentStatus(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, status)
+}
+
+// AwaitDeployment long-polls a deployment transaction by hash alone until
+// it is mined, its contract code is confirmed on chain, and the requested
+// confirmation policy is satisfied, then returns the finalized
+// DeploymentResponse. Because it only needs txHash, a caller can use it to
+// resume tracking a deployment after losing the original request (e.g. a
+// service restart) rather than having to re-poll GetDeploymentStatus itself.
+func (s *Service) AwaitDeployment(c *gin.Context) {
+	txHash := c.Param("txHash")
+	if txHash == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Transaction hash is required"})
+		return
+	}
+
+	opts := eth.WaitOptions{Confirmations: eth.Confirmations(1)}
+	switch tag := c.Query("confirmations"); tag {
+	case "":
+	case "safe":
+		opts.Confirmations = eth.SafeConfirmations()
+	case "finalized":
+		opts.Confirmations = eth.FinalizedConfirmations()
+	default:
+		n, err := strconv.ParseUint(tag, 10, 64)
+		if err != nil || n == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "confirmations must be a positive integer, \"safe\", or \"finalized\""})
+			return
+		}
+		opts.Confirmations = eth.Confirmations(n)
+	}
+
+	// Long-poll: this can legitimately take minutes waiting for
+	// confirmations, so the timeout here is generous rather than the
+	// request-scoped ones used elsewhere in this file.
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Minute)
+	defer cancel()
+
+	result, err := s.ethClient.WaitDeployed(ctx, txHash, opts)
+	if err != nil {
+		c.JSON(http.StatusGatewayTimeout, gin.H{
+			"error":   "Timed out waiting for deployment",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, DeploymentResponse{
+		TxHash:          result.TxHash,
+		ContractAddress: result.ContractAddress,
+		Cost:            result.Cost,
+		BlockNumber:     result.BlockNumber,
+	})
 }
\ No newline at end of file
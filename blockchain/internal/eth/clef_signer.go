@@ -0,0 +1,107 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// clefSendTxArgs mirrors the subset of clef's SendTxArgs that
+// account_signTransaction requires to reconstruct an unsigned transaction.
+// Like go-ethereum's own TransactionArgs, clef infers the transaction type
+// from which fee fields are set: GasPrice alone for a legacy type-0 tx, or
+// MaxFeePerGas/MaxPriorityFeePerGas for an EIP-1559 type-2 tx.
+type clefSendTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 *hexutil.Bytes  `json:"data,omitempty"`
+	ChainID              *hexutil.Big    `json:"chainId,omitempty"`
+}
+
+// clefSignTransactionResult is clef's response to account_signTransaction.
+type clefSignTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+// ClefSigner signs transactions by delegating to a remote clef instance
+// over its external-signer JSON-RPC API, so the private key never leaves
+// the clef process.
+type ClefSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewClefSigner dials the clef external signer listening at endpoint
+// (typically a unix socket or http URL) and configures it to sign on
+// behalf of address, which must already be approved in clef's rules.
+func NewClefSigner(endpoint string, address common.Address) (*ClefSigner, error) {
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clef at %s: %v", endpoint, err)
+	}
+	return &ClefSigner{client: client, address: address}, nil
+}
+
+// Address implements Signer.
+func (s *ClefSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx implements Signer. It asks clef to sign the transaction and
+// returns the transaction clef actually produced, since clef may adjust
+// fields according to its own rules.
+func (s *ClefSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var data *hexutil.Bytes
+	if len(tx.Data()) > 0 {
+		raw := hexutil.Bytes(tx.Data())
+		data = &raw
+	}
+
+	args := clefSendTxArgs{
+		From:  s.address,
+		To:    tx.To(),
+		Gas:   hexutil.Uint64(tx.Gas()),
+		Value: (*hexutil.Big)(tx.Value()),
+		Nonce: hexutil.Uint64(tx.Nonce()),
+		Data:  data,
+	}
+	if chainID != nil {
+		args.ChainID = (*hexutil.Big)(chainID)
+	}
+
+	// tx.GasPrice() returns GasFeeCap for a DynamicFeeTx, so it can't be
+	// used unconditionally: doing so would silently ask clef to sign a
+	// legacy transaction with the fee cap as a flat gas price, losing the
+	// priority-fee distinction entirely.
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	var result clefSignTransactionResult
+	if err := s.client.CallContext(ctx, &result, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("clef signing failed: %v", err)
+	}
+	if result.Tx == nil {
+		return nil, fmt.Errorf("clef returned no signed transaction")
+	}
+	return result.Tx, nil
+}
@@ -1,107 +1,309 @@
 package eth
 
 import (
+	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"net"
 	"os"
 	"strings"
-	"bytes"
-	
+	"sync/atomic"
+	"time"
+
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
-	solc "github.com/ethereum/go-ethereum/common/compiler"
-	
+
 	"github.com/yourusername/smart-contract-intelligence/pkg/types"
 )
 
-// Client represents an Ethereum client
+// healthCheckInterval controls how often the background goroutine re-pings
+// every configured endpoint's ChainID to detect recovery/outage.
+const healthCheckInterval = 30 * time.Second
+
+// endpoint tracks one RPC connection in the pool along with whether the
+// last request against it succeeded.
+type endpoint struct {
+	url     string
+	client  Backend
+	healthy int32 // atomic bool: 1 = healthy, 0 = unhealthy
+}
+
+// Client represents a pool of Ethereum JSON-RPC endpoints behind a single
+// logical client, with round-robin dispatch and automatic failover.
 type Client struct {
-	client  *ethclient.Client
-	chainID *big.Int
+	endpoints []*endpoint
+	chainID   *big.Int
+	nextIdx   uint64 // atomic round-robin counter
+	signer    Signer
 }
 
 // DeploymentResult represents the result of a contract deployment
 type DeploymentResult struct {
-	TxHash         string
+	TxHash          string
 	ContractAddress string
-	BlockNumber    int
-	GasUsed        uint64
-	GasPrice       *big.Int
-	Cost           float64
+	BlockNumber     int
+	GasUsed         uint64
+	// GasPrice is the effective price paid per unit of gas: the legacy gas
+	// price for a GasStrategyLegacy deployment, or the effective gas price
+	// (min(MaxFeePerGas, baseFee+tip)) for an EIP-1559 one.
+	GasPrice *big.Int
+	// MaxFeePerGas and MaxPriorityFeePerGas are set when the deployment used
+	// an EIP-1559 dynamic fee transaction.
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	Cost                 float64
 }
 
 // TransactionStatus represents the status of a transaction
 type TransactionStatus struct {
-	Status      string  `json:"status"`       // "pending", "success", "failed"
-	BlockNumber uint64  `json:"block_number"` // Block number if mined
-	Confirmations uint64 `json:"confirmations"` // Number of confirmations
-	GasUsed     uint64  `json:"gas_used"`     // Gas used if mined
-	Cost        float64 `json:"cost"`         // Cost in ETH
+	Status               string   `json:"status"`                // "pending", "success", "failed"
+	BlockNumber          uint64   `json:"block_number"`          // Block number if mined
+	Confirmations        uint64   `json:"confirmations"`         // Number of confirmations
+	GasUsed              uint64   `json:"gas_used"`               // Gas used if mined
+	Cost                 float64  `json:"cost"`                   // Cost in ETH
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas,omitempty"`
 }
 
-// NewEthClient creates a new Ethereum client
-func NewEthClient(rpcURL string) (*Client, error) {
-	if rpcURL == "" {
+// NewEthClient creates a new Ethereum client backed by one or more RPC
+// endpoints (comma-separated, e.g. "https://infura/...,https://alchemy/...,
+// http://localhost:8545"). Every endpoint must agree on the same chain ID;
+// mismatched configuration is rejected at startup rather than surfacing as
+// confusing mid-request errors. A background goroutine periodically
+// re-checks unhealthy endpoints so the pool recovers once they come back.
+// signer supplies the account deployments and writes are sent from; see
+// Signer and its implementations (KeystoreSigner, ClefSigner,
+// PlaintextEnvSigner) for how the key material itself is held.
+func NewEthClient(rpcURLs string, signer Signer) (*Client, error) {
+	if rpcURLs == "" {
 		return nil, errors.New("Ethereum RPC URL is required")
 	}
+	if signer == nil {
+		return nil, errors.New("a signer is required")
+	}
 
-	client, err := ethclient.Dial(rpcURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum client: %v", err)
+	var urls []string
+	for _, raw := range strings.Split(rpcURLs, ",") {
+		url := strings.TrimSpace(raw)
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) == 0 {
+		return nil, errors.New("Ethereum RPC URL is required")
 	}
 
-	// Get the chain ID
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	
-	chainID, err := client.ChainID(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+
+	var endpoints []*endpoint
+	var chainID *big.Int
+	for _, url := range urls {
+		dialed, err := ethclient.Dial(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Ethereum endpoint %s: %v", url, err)
+		}
+
+		id, err := dialed.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain ID from %s: %v", url, err)
+		}
+		if chainID == nil {
+			chainID = id
+		} else if chainID.Cmp(id) != 0 {
+			return nil, fmt.Errorf("endpoint %s reports chain ID %s, expected %s", url, id, chainID)
+		}
+
+		endpoints = append(endpoints, &endpoint{url: url, client: dialed, healthy: 1})
 	}
 
-	return &Client{
-		client:  client,
-		chainID: chainID,
-	}, nil
+	client := &Client{endpoints: endpoints, chainID: chainID, signer: signer}
+	go client.healthCheckLoop()
+	return client, nil
+}
+
+// healthCheckLoop periodically pings every endpoint's ChainID, marking it
+// healthy or unhealthy based on whether the call succeeds.
+func (c *Client) healthCheckLoop() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, ep := range c.endpoints {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			_, err := ep.client.ChainID(ctx)
+			cancel()
+
+			if err != nil {
+				if atomic.SwapInt32(&ep.healthy, 0) == 1 {
+					log.Printf("eth: endpoint %s failed health check: %v", ep.url, err)
+				}
+				continue
+			}
+			if atomic.SwapInt32(&ep.healthy, 1) == 0 {
+				log.Printf("eth: endpoint %s recovered", ep.url)
+			}
+		}
+	}
+}
+
+// isTransientError reports whether err looks like a network-level failure
+// (dial error, timeout, 5xx) that warrants failing over to another
+// endpoint, as opposed to a semantic result like ethereum.NotFound that
+// every endpoint would agree on.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	msg := err.Error()
+	for _, marker := range []string{"connection refused", "timeout", "EOF", "no such host", "TLS handshake", "502", "503", "504"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// do runs fn against a healthy endpoint, round-robining across the pool and
+// failing over to the next endpoint on a transient error. A non-transient
+// error (e.g. ethereum.NotFound) is returned immediately without trying
+// other endpoints, since every endpoint should agree on it.
+func (c *Client) do(fn func(Backend) error) error {
+	if len(c.endpoints) == 0 {
+		return errors.New("no Ethereum endpoints configured")
+	}
+
+	start := int(atomic.AddUint64(&c.nextIdx, 1))
+	var lastErr error
+	triedAny := false
+
+	for i := 0; i < len(c.endpoints); i++ {
+		ep := c.endpoints[(start+i)%len(c.endpoints)]
+		if atomic.LoadInt32(&ep.healthy) == 0 {
+			continue
+		}
+		triedAny = true
+
+		err := fn(ep.client)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if isTransientError(err) {
+			atomic.StoreInt32(&ep.healthy, 0)
+			log.Printf("eth: endpoint %s failed, failing over: %v", ep.url, err)
+			continue
+		}
+		return err
+	}
+
+	if !triedAny {
+		// Every endpoint is marked unhealthy; degrade by trying this call's
+		// round-robin pick anyway rather than refusing outright.
+		return fn(c.endpoints[start%len(c.endpoints)].client)
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no healthy Ethereum endpoints available")
+	}
+	return lastErr
 }
 
-// GetPrivateKey retrieves a private key from environment or keystore
-func (c *Client) GetPrivateKey() (*ecdsa.PrivateKey, error) {
-	// For production, use a secure key management system
-	// This is a simplified example that uses an environment variable
-	privateKeyHex := os.Getenv("ETH_PRIVATE_KEY")
-	if privateKeyHex == "" {
-		return nil, errors.New("ETH_PRIVATE_KEY environment variable not set")
+// pickForSend returns a single healthy Backend, in round-robin order, to
+// send a transaction through, falling back to the round-robin pick itself
+// when no endpoint is marked healthy.
+func (c *Client) pickForSend() (Backend, error) {
+	if len(c.endpoints) == 0 {
+		return nil, errors.New("no Ethereum endpoints configured")
+	}
+	start := int(atomic.AddUint64(&c.nextIdx, 1))
+	for i := 0; i < len(c.endpoints); i++ {
+		ep := c.endpoints[(start+i)%len(c.endpoints)]
+		if atomic.LoadInt32(&ep.healthy) == 1 {
+			return ep.client, nil
+		}
 	}
+	return c.endpoints[start%len(c.endpoints)].client, nil
+}
 
-	// Remove 0x prefix if present
-	if strings.HasPrefix(privateKeyHex, "0x") {
-		privateKeyHex = privateKeyHex[2:]
+// DeployerAddress returns the address that DeployContract will sign
+// transactions from, derived from the configured Signer.
+func (c *Client) DeployerAddress() (common.Address, error) {
+	if c.signer == nil {
+		return common.Address{}, errors.New("no signer configured")
 	}
+	return c.signer.Address(), nil
+}
+
+// Signer returns the Signer this client deploys and writes with, so other
+// clients (e.g. one dialed for a different chain) can be configured to
+// sign from the same account.
+func (c *Client) Signer() Signer {
+	return c.signer
+}
 
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+// NextNonce returns the next pending nonce for the deployer's account, the
+// starting point for a sequence of sequentially-nonced transactions.
+func (c *Client) NextNonce(ctx context.Context, address common.Address) (uint64, error) {
+	var nonce uint64
+	err := c.do(func(ec Backend) error {
+		n, err := ec.PendingNonceAt(ctx, address)
+		if err != nil {
+			return err
+		}
+		nonce = n
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %v", err)
+		return 0, fmt.Errorf("failed to get nonce: %v", err)
 	}
+	return nonce, nil
+}
 
-	return privateKey, nil
+// PredictContractAddress predicts the address a CREATE (not CREATE2)
+// deployment from sender at the given nonce will end up at, i.e.
+// keccak256(rlp([sender, nonce]))[12:].
+func PredictContractAddress(sender common.Address, nonce uint64) common.Address {
+	return crypto.CreateAddress(sender, nonce)
 }
 
 // DeployContract deploys a smart contract to the blockchain
-func (c *Client) DeployContract(ctx context.Context, req *types.DeploymentRequest) (*DeploymentResult, error) {
+// SentDeployment is the outcome of submitting a deployment transaction
+// before its receipt is known.
+type SentDeployment struct {
+	TxHash               string
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// SendDeployment compiles, builds, signs, and submits a deployment
+// transaction, returning as soon as it is accepted by the mempool rather
+// than waiting for it to be mined. Callers that need to submit several
+// deployments back-to-back with sequential nonces (e.g. DeployBundle) use
+// this to flush every transaction before waiting on any of them, then call
+// WaitDeployed per tx hash afterward. DeployContract is SendDeployment
+// followed immediately by WaitDeployed.
+func (c *Client) SendDeployment(ctx context.Context, req *types.DeploymentRequest) (*SentDeployment, error) {
 	// Compile the Solidity code
-	contracts, err := c.CompileContract(req.ContractCode)
+	contracts, _, err := c.CompileContract(req.ContractCode)
 	if err != nil {
 		return nil, fmt.Errorf("compilation failed: %v", err)
 	}
@@ -112,7 +314,7 @@ func (c *Client) DeployContract(ctx context.Context, req *types.DeploymentReques
 
 	// Find the main contract (usually the last one or the one with matching name)
 	var contractName string
-	var compiledContract *solc.Contract
+	var compiledContract *CompiledContract
 
 	// Try to extract contract name from metadata if available
 	if req.Metadata != nil {
@@ -141,63 +343,40 @@ func (c *Client) DeployContract(ctx context.Context, req *types.DeploymentReques
 		}
 	}
 
-	// Get the contract ABI and bytecode
-	contractAbi, err := json.Marshal(compiledContract.Info.AbiDefinition)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal ABI: %v", err)
-	}
-
-	parsedAbi, err := abi.JSON(strings.NewReader(string(contractAbi)))
+	parsedAbi, err := abi.JSON(bytes.NewReader(compiledContract.ABI))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ABI: %v", err)
 	}
 
-	bytecode := common.FromHex(compiledContract.Code)
+	bytecode := compiledContract.Bytecode
 	if len(bytecode) == 0 {
 		return nil, errors.New("empty bytecode")
 	}
 
-	// Get the private key for transaction signing
-	privateKey, err := c.GetPrivateKey()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get private key: %v", err)
+	if c.signer == nil {
+		return nil, errors.New("no signer configured")
 	}
 
-	// Create a new transaction signer
+	// Determine the chain to sign for
 	chainID := big.NewInt(int64(req.ChainID))
 	if chainID.Cmp(big.NewInt(0)) == 0 {
 		chainID = c.chainID // Use the client's chain ID if not specified
 	}
 
 	// Get the deployer's address
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, errors.New("error casting public key to ECDSA")
-	}
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-
-	// Get the nonce for the sender's account
-	nonce, err := c.client.PendingNonceAt(ctx, fromAddress)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %v", err)
-	}
-
-	// Get gas price
-	gasPrice, err := c.client.SuggestGasPrice(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to suggest gas price: %v", err)
-	}
+	fromAddress := c.signer.Address()
 
-	// Set up the transaction options
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create transactor: %v", err)
+	// Get the nonce for the sender's account, unless the caller pinned one
+	// (e.g. to match a previously predicted CREATE address).
+	var nonce uint64
+	if req.Nonce != nil {
+		nonce = *req.Nonce
+	} else {
+		nonce, err = c.NextNonce(ctx, fromAddress)
+		if err != nil {
+			return nil, err
+		}
 	}
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)     // No ether transfer
-	auth.GasLimit = req.GasLimit   // Gas limit
-	auth.GasPrice = gasPrice       // Gas price
 
 	// Process constructor arguments if provided
 	var constructorArgs []interface{}
@@ -212,7 +391,7 @@ func (c *Client) DeployContract(ctx context.Context, req *types.DeploymentReques
 	// Encode the constructor arguments with the contract bytecode
 	var data []byte
 	var encodingErr error
-	
+
 	if len(constructorArgs) > 0 {
 		data, encodingErr = parsedAbi.Pack("", constructorArgs...)
 		if encodingErr != nil {
@@ -223,79 +402,193 @@ func (c *Client) DeployContract(ctx context.Context, req *types.DeploymentReques
 		data = bytecode
 	}
 
-	// Create the transaction
-	tx := types.NewContractCreation(nonce, big.NewInt(0), auth.GasLimit, gasPrice, data)
-	
-	// Sign the transaction
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
+	// Build the transaction per the requested gas strategy: a legacy type-0
+	// tx priced via SuggestGasPrice, or an EIP-1559 type-2 dynamic fee tx
+	// priced either automatically from the chain's base fee or manually by
+	// the caller.
+	tx, effectiveGasPrice, maxFeePerGas, maxPriorityFeePerGas, err := c.buildDeploymentTx(ctx, req, chainID, nonce, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the transaction via the configured Signer, so the private key
+	// itself never has to pass through this function.
+	signedTx, err := c.signer.SignTx(tx, chainID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign transaction: %v", err)
 	}
 
-	// Send the transaction
-	err = c.client.SendTransaction(ctx, signedTx)
+	// Send the transaction through a single endpoint picked by pickForSend;
+	// WaitDeployed then polls for its receipt across the whole pool.
+	sender, err := c.pickForSend()
 	if err != nil {
+		return nil, err
+	}
+	if err := sender.SendTransaction(ctx, signedTx); err != nil {
 		return nil, fmt.Errorf("failed to send transaction: %v", err)
 	}
 
-	// Wait for the transaction receipt
-	receipt, err := bind.WaitMined(ctx, c.client, signedTx)
+	return &SentDeployment{
+		TxHash:               signedTx.Hash().Hex(),
+		GasPrice:             effectiveGasPrice,
+		MaxFeePerGas:         maxFeePerGas,
+		MaxPriorityFeePerGas: maxPriorityFeePerGas,
+	}, nil
+}
+
+// DeployContract submits a deployment transaction via SendDeployment and
+// waits for it to be deployed via WaitDeployed, the same path a caller
+// resuming tracking by tx hash alone (e.g. after a service restart, via the
+// /deploy/:txHash/await endpoint) uses.
+func (c *Client) DeployContract(ctx context.Context, req *types.DeploymentRequest) (*DeploymentResult, error) {
+	sent, err := c.SendDeployment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.WaitDeployed(ctx, sent.TxHash, WaitOptions{Confirmations: Confirmations(1)})
 	if err != nil {
-		// If we timeout waiting for the receipt, return the tx hash anyway
-		// so the status can be checked later
-		log.Printf("Warning: Timeout waiting for transaction receipt: %v", err)
+		if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		// If we timeout or the caller cancels while waiting, return the tx
+		// hash anyway so tracking can be resumed later.
+		log.Printf("Warning: timed out waiting for deployment %s: %v", sent.TxHash, err)
 		return &DeploymentResult{
-			TxHash: signedTx.Hash().Hex(),
+			TxHash:               sent.TxHash,
+			GasPrice:             sent.GasPrice,
+			MaxFeePerGas:         sent.MaxFeePerGas,
+			MaxPriorityFeePerGas: sent.MaxPriorityFeePerGas,
 		}, nil
 	}
 
-	// Calculate the cost in ETH
-	gasUsed := receipt.GasUsed
-	gasCost := new(big.Float).Mul(
-		new(big.Float).SetInt(gasPrice),
-		new(big.Float).SetUint64(gasUsed),
-	)
-	
-	// Convert from wei to ETH
-	weiPerEth := new(big.Float).SetInt(big.NewInt(1e18))
-	costInEth := new(big.Float).Quo(gasCost, weiPerEth)
-	
-	ethCost, _ := costInEth.Float64()
-
-	return &DeploymentResult{
-		TxHash:         signedTx.Hash().Hex(),
-		ContractAddress: receipt.ContractAddress.Hex(),
-		BlockNumber:    int(receipt.BlockNumber.Int64()),
-		GasUsed:        receipt.GasUsed,
-		GasPrice:       gasPrice,
-		Cost:           ethCost,
-	}, nil
+	return result, nil
 }
 
-// CompileContract compiles a Solidity contract
-func (c *Client) CompileContract(sourceCode string) (map[string]*solc.Contract, error) {
-	// Write the source code to a temporary file
-	tmpFile, err := os.CreateTemp("", "solidity-*.sol")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temporary file: %v", err)
+// buildDeploymentTx constructs the unsigned deployment transaction for
+// req.GasStrategy, returning the transaction along with the fee values that
+// will end up in the DeploymentResult.
+func (c *Client) buildDeploymentTx(ctx context.Context, req *types.DeploymentRequest, chainID *big.Int, nonce uint64, data []byte) (*types.Transaction, *big.Int, *big.Int, *big.Int, error) {
+	strategy := req.GasStrategy
+	if strategy == "" {
+		strategy = types.GasStrategyLegacy
+	}
+
+	if strategy == types.GasStrategyLegacy {
+		var gasPrice *big.Int
+		if err := c.do(func(ec Backend) error {
+			price, err := ec.SuggestGasPrice(ctx)
+			if err != nil {
+				return err
+			}
+			gasPrice = price
+			return nil
+		}); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to suggest gas price: %v", err)
+		}
+		tx := types.NewContractCreation(nonce, big.NewInt(0), req.GasLimit, gasPrice, data)
+		return tx, gasPrice, nil, nil, nil
+	}
+
+	// EIP-1559 (automatic or manual): fetch the latest base fee to both
+	// validate the chain supports it and, for the automatic case, derive
+	// the fee cap from it.
+	var baseFee *big.Int
+	if err := c.do(func(ec Backend) error {
+		header, err := ec.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+		baseFee = header.BaseFee
+		return nil
+	}); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to fetch latest header: %v", err)
 	}
-	defer os.Remove(tmpFile.Name())
-
-	if _, err := tmpFile.WriteString(sourceCode); err != nil {
-		return nil, fmt.Errorf("failed to write to temporary file: %v", err)
+	if baseFee == nil {
+		return nil, nil, nil, nil, errors.New("connected chain does not support EIP-1559 (no base fee in latest header)")
 	}
-	
-	if err := tmpFile.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close temporary file: %v", err)
+
+	var maxFeePerGas, maxPriorityFeePerGas *big.Int
+	if strategy == types.GasStrategyManual {
+		if req.MaxFeePerGas == nil || req.MaxPriorityFeePerGas == nil {
+			return nil, nil, nil, nil, errors.New("manual gas strategy requires max_fee_per_gas and max_priority_fee_per_gas")
+		}
+		maxFeePerGas = req.MaxFeePerGas
+		maxPriorityFeePerGas = req.MaxPriorityFeePerGas
+	} else {
+		if err := c.do(func(ec Backend) error {
+			tip, err := ec.SuggestGasTipCap(ctx)
+			if err != nil {
+				return err
+			}
+			maxPriorityFeePerGas = tip
+			return nil
+		}); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to suggest gas tip cap: %v", err)
+		}
+		maxFeePerGas = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), maxPriorityFeePerGas)
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: maxPriorityFeePerGas,
+		GasFeeCap: maxFeePerGas,
+		Gas:       req.GasLimit,
+		To:        nil,
+		Value:     big.NewInt(0),
+		Data:      data,
+	})
+
+	effectiveGasPrice := new(big.Int).Add(baseFee, maxPriorityFeePerGas)
+	if effectiveGasPrice.Cmp(maxFeePerGas) > 0 {
+		effectiveGasPrice = maxFeePerGas
 	}
 
-	// Compile the contract
-	contracts, err := solc.CompileSolidity("solc", tmpFile.Name())
+	return tx, effectiveGasPrice, maxFeePerGas, maxPriorityFeePerGas, nil
+}
+
+// FilterLogs retrieves historical logs emitted by a contract, using
+// eth_getLogs under the hood. topics follows the standard JSON-RPC topic
+// filter shape: topics[0] matches against the first topic (the event
+// signature), topics[1] against the second, and so on; a nil entry means
+// "any value".
+func (c *Client) FilterLogs(ctx context.Context, contractAddress common.Address, topics [][]common.Hash, fromBlock, toBlock *big.Int) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{contractAddress},
+		Topics:    topics,
+	}
+
+	var logs []types.Log
+	err := c.do(func(ec Backend) error {
+		result, err := ec.FilterLogs(ctx, query)
+		if err != nil {
+			return err
+		}
+		logs = result
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("compilation failed: %v", err)
+		return nil, fmt.Errorf("failed to filter logs: %v", err)
 	}
+	return logs, nil
+}
+
+// CompileContract compiles sourceCode with the pinned DefaultSolcVersion
+// (override per-call with ETH_SOLC_VERSION) via the Standard JSON pipeline,
+// returning every contract found keyed "<file>:<contract>" and the raw
+// Standard JSON input that produced them, so callers that need
+// reproducibility (e.g. deployment records) can persist it.
+func (c *Client) CompileContract(sourceCode string) (map[string]*CompiledContract, []byte, error) {
+	version := os.Getenv("ETH_SOLC_VERSION")
+	input := DefaultSolcInput("contract.sol", sourceCode, version)
 
-	return contracts, nil
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	return c.CompileStandardJSON(ctx, input)
 }
 
 // GetTransactionStatus checks the status of a transaction
@@ -303,9 +596,17 @@ func (c *Client) GetTransactionStatus(ctx context.Context, txHash string) (*Tran
 	hash := common.HexToHash(txHash)
 
 	// Try to get transaction receipt
-	receipt, err := c.client.TransactionReceipt(ctx, hash)
+	var receipt *types.Receipt
+	err := c.do(func(ec Backend) error {
+		r, err := ec.TransactionReceipt(ctx, hash)
+		if err != nil {
+			return err
+		}
+		receipt = r
+		return nil
+	})
 	if err != nil {
-		if err == ethereum.NotFound {
+		if errors.Is(err, ethereum.NotFound) {
 			// Transaction is still pending
 			return &TransactionStatus{
 				Status:       "pending",
@@ -316,8 +617,15 @@ func (c *Client) GetTransactionStatus(ctx context.Context, txHash string) (*Tran
 	}
 
 	// Get the current block number to calculate confirmations
-	header, err := c.client.HeaderByNumber(ctx, nil)
-	if err != nil {
+	var header *types.Header
+	if err := c.do(func(ec Backend) error {
+		h, err := ec.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+		header = h
+		return nil
+	}); err != nil {
 		return nil, fmt.Errorf("failed to get current block header: %v", err)
 	}
 
@@ -325,23 +633,34 @@ func (c *Client) GetTransactionStatus(ctx context.Context, txHash string) (*Tran
 	confirmations := header.Number.Uint64() - receipt.BlockNumber.Uint64()
 
 	// Get the transaction to calculate cost
-	tx, _, err := c.client.TransactionByHash(ctx, hash)
-	if err != nil {
+	var tx *types.Transaction
+	if err := c.do(func(ec Backend) error {
+		t, _, err := ec.TransactionByHash(ctx, hash)
+		if err != nil {
+			return err
+		}
+		tx = t
+		return nil
+	}); err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %v", err)
 	}
 
-	// Calculate the transaction cost
-	gasPrice := tx.GasPrice()
+	// Calculate the transaction cost, preferring the receipt's effective gas
+	// price (accurate for both legacy and EIP-1559 transactions).
+	effectiveGasPrice := receipt.EffectiveGasPrice
+	if effectiveGasPrice == nil {
+		effectiveGasPrice = tx.GasPrice()
+	}
 	gasUsed := receipt.GasUsed
 	gasCost := new(big.Float).Mul(
-		new(big.Float).SetInt(gasPrice),
+		new(big.Float).SetInt(effectiveGasPrice),
 		new(big.Float).SetUint64(gasUsed),
 	)
-	
+
 	// Convert from wei to ETH
 	weiPerEth := new(big.Float).SetInt(big.NewInt(1e18))
 	costInEth := new(big.Float).Quo(gasCost, weiPerEth)
-	
+
 	ethCost, _ := costInEth.Float64()
 
 	// Determine status
@@ -350,19 +669,24 @@ func (c *Client) GetTransactionStatus(ctx context.Context, txHash string) (*Tran
 		status = "success"
 	}
 
-	return &TransactionStatus{
-		Status:       status,
-		BlockNumber:  receipt.BlockNumber.Uint64(),
+	result := &TransactionStatus{
+		Status:        status,
+		BlockNumber:   receipt.BlockNumber.Uint64(),
 		Confirmations: confirmations,
-		GasUsed:      receipt.GasUsed,
-		Cost:         ethCost,
-	}, nil
+		GasUsed:       receipt.GasUsed,
+		Cost:          ethCost,
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.MaxFeePerGas = tx.GasFeeCap()
+		result.MaxPriorityFeePerGas = tx.GasTipCap()
+	}
+	return result, nil
 }
 
 // EstimateDeploymentCost estimates the cost of deploying a contract
 func (c *Client) EstimateDeploymentCost(ctx context.Context, contractCode string) (float64, error) {
 	// Compile the contract
-	contracts, err := c.CompileContract(contractCode)
+	contracts, _, err := c.CompileContract(contractCode)
 	if err != nil {
 		return 0, fmt.Errorf("compilation failed: %v", err)
 	}
@@ -374,35 +698,42 @@ func (c *Client) EstimateDeploymentCost(ctx context.Context, contractCode string
 	// Get the compiled contract bytecode (use the first contract)
 	var bytecode []byte
 	for _, contract := range contracts {
-		bytecode = common.FromHex(contract.Code)
+		bytecode = contract.Bytecode
 		break
 	}
 
 	// Get the sender's address (we need an address for the estimation)
-	privateKey, err := c.GetPrivateKey()
-	if err != nil {
-		return 0, fmt.Errorf("failed to get private key: %v", err)
+	if c.signer == nil {
+		return 0, errors.New("no signer configured")
 	}
-	
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return 0, errors.New("error casting public key to ECDSA")
-	}
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
+	fromAddress := c.signer.Address()
 
 	// Estimate gas
-	gasLimit, err := c.client.EstimateGas(ctx, ethereum.CallMsg{
-		From: fromAddress,
-		Data: bytecode,
-	})
-	if err != nil {
+	var gasLimit uint64
+	if err := c.do(func(ec Backend) error {
+		limit, err := ec.EstimateGas(ctx, ethereum.CallMsg{
+			From: fromAddress,
+			Data: bytecode,
+		})
+		if err != nil {
+			return err
+		}
+		gasLimit = limit
+		return nil
+	}); err != nil {
 		return 0, fmt.Errorf("failed to estimate gas: %v", err)
 	}
 
 	// Get current gas price
-	gasPrice, err := c.client.SuggestGasPrice(ctx)
-	if err != nil {
+	var gasPrice *big.Int
+	if err := c.do(func(ec Backend) error {
+		price, err := ec.SuggestGasPrice(ctx)
+		if err != nil {
+			return err
+		}
+		gasPrice = price
+		return nil
+	}); err != nil {
 		return 0, fmt.Errorf("failed to get gas price: %v", err)
 	}
 
@@ -411,55 +742,14 @@ func (c *Client) EstimateDeploymentCost(ctx context.Context, contractCode string
 		new(big.Float).SetInt(gasPrice),
 		new(big.Float).SetUint64(gasLimit),
 	)
-	
+
 	// Convert from wei to ETH
 	weiPerEth := new(big.Float).SetInt(big.NewInt(1e18))
 	costInEth := new(big.Float).Quo(gasCost, weiPerEth)
-	
-	ethCost, _ := costInEth.Float64()
-	
-	return ethCost, nil
-}
-
-// VerifyContractSource verifies a deployed contract's source code
-func (c *Client) VerifyContractSource(
-	ctx context.Context,
-	contractAddress string,
-	sourceCode string,
-	constructorArgs string,
-) (bool, error) {
-	// This is a simplified implementation
-	// In a production environment, you would interact with Etherscan API
-	// or another block explorer's API to verify the contract
-
-	// Compile the provided source code
-	contracts, err := c.CompileContract(sourceCode)
-	if err != nil {
-		return false, fmt.Errorf("compilation failed: %v", err)
-	}
 
-	// Get the bytecode of the deployed contract
-	address := common.HexToAddress(contractAddress)
-	deployedBytecode, err := c.client.CodeAt(ctx, address, nil)
-	if err != nil {
-		return false, fmt.Errorf("failed to get deployed bytecode: %v", err)
-	}
-
-	// Compare bytecodes (with some simplifications)
-	// Note: In reality, this comparison is more complex due to constructor arguments,
-	// optimizations, and metadata differences
-	for _, contract := range contracts {
-		compiledBytecode := common.FromHex(contract.Code)
-		
-		// This is a simplified comparison
-		// In a real implementation, you would need to handle constructor arguments,
-		// compiler metadata, and other differences
-		if len(deployedBytecode) > 0 && bytes.HasPrefix(deployedBytecode, compiledBytecode) {
-			return true, nil
-		}
-	}
+	ethCost, _ := costInEth.Float64()
 
-	return false, nil
+	return ethCost, nil
 }
 
 // InteractWithContract interacts with a deployed contract
@@ -483,17 +773,25 @@ func (c *Client) InteractWithContract(
 	}
 
 	// Create the call message
+	to := common.HexToAddress(contractAddress)
 	msg := ethereum.CallMsg{
-		To:   &common.HexToAddress(contractAddress),
+		To:   &to,
 		Data: data,
 	}
 
 	// Execute the call
-	result, err := c.client.CallContract(ctx, msg, nil)
-	if err != nil {
+	var result []byte
+	if err := c.do(func(ec Backend) error {
+		r, err := ec.CallContract(ctx, msg, nil)
+		if err != nil {
+			return err
+		}
+		result = r
+		return nil
+	}); err != nil {
 		return "", fmt.Errorf("contract call failed: %v", err)
 	}
 
 	// Return the raw result
 	return hexutil.Encode(result), nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,147 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	scitypes "github.com/yourusername/smart-contract-intelligence/pkg/types"
+)
+
+// storeContractSource is a minimal Solidity contract whose constructor
+// stores its single uint256 argument, used to exercise constructor
+// argument encoding end to end alongside DeployContract and
+// InteractWithContract against the SimulatedBackend.
+const storeContractSource = `
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+
+contract Store {
+    uint256 public value;
+
+    constructor(uint256 _value) {
+        value = _value;
+    }
+
+    function retrieve() public view returns (uint256) {
+        return value;
+    }
+}
+`
+
+// requireSolc skips the test if the pinned solc version can't be resolved
+// (e.g. no network access to solc-bin), so running these tests doesn't
+// require the sandbox they run in to have internet access.
+func requireSolc(t *testing.T) {
+	t.Helper()
+	if _, err := resolveSolcBinary(DefaultSolcVersion); err != nil {
+		t.Skipf("solc %s not available: %v", DefaultSolcVersion, err)
+	}
+}
+
+// newTestSigner returns a Signer backed by a freshly generated key, for
+// tests that need to sign against a SimulatedBackend rather than a real
+// account.
+func newTestSigner(t *testing.T) Signer {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+	return &PlaintextEnvSigner{privateKey: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// newSimulatedClient returns a Client backed by a fresh SimulatedBackend
+// prefunded for signer's account, the same setup DryRunDeploy uses.
+func newSimulatedClient(t *testing.T, signer Signer) *Client {
+	t.Helper()
+	sim := NewSimulatedBackend(signer.Address())
+	chainID, err := sim.ChainID(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get simulated chain ID: %v", err)
+	}
+	return &Client{
+		endpoints: []*endpoint{{url: "simulated", client: sim, healthy: 1}},
+		chainID:   chainID,
+		signer:    signer,
+	}
+}
+
+func TestDeployContract_ConstructorArgumentEncoding(t *testing.T) {
+	requireSolc(t)
+
+	client := newSimulatedClient(t, newTestSigner(t))
+
+	result, err := client.DeployContract(context.Background(), &scitypes.DeploymentRequest{
+		ContractCode:         storeContractSource,
+		ConstructorArguments: "[42]",
+		GasLimit:             3_000_000,
+	})
+	if err != nil {
+		t.Fatalf("DeployContract failed: %v", err)
+	}
+	if result.ContractAddress == "" {
+		t.Fatal("DeployContract returned no contract address")
+	}
+}
+
+func TestInteractWithContract_ReadsConstructorValue(t *testing.T) {
+	requireSolc(t)
+
+	client := newSimulatedClient(t, newTestSigner(t))
+
+	contracts, _, err := client.CompileContract(storeContractSource)
+	if err != nil {
+		t.Fatalf("CompileContract failed: %v", err)
+	}
+	var compiled *CompiledContract
+	for _, c := range contracts {
+		compiled = c
+		break
+	}
+	if compiled == nil {
+		t.Fatal("CompileContract returned no contracts")
+	}
+	parsedABI, err := abi.JSON(bytes.NewReader(compiled.ABI))
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+
+	result, err := client.DeployContract(context.Background(), &scitypes.DeploymentRequest{
+		ContractCode:         storeContractSource,
+		ConstructorArguments: "[7]",
+		GasLimit:             3_000_000,
+	})
+	if err != nil {
+		t.Fatalf("DeployContract failed: %v", err)
+	}
+
+	raw, err := client.InteractWithContract(context.Background(), result.ContractAddress, string(compiled.ABI), "retrieve", nil)
+	if err != nil {
+		t.Fatalf("InteractWithContract failed: %v", err)
+	}
+
+	rawBytes, err := hexutil.Decode(raw)
+	if err != nil {
+		t.Fatalf("failed to decode call result: %v", err)
+	}
+	decoded, err := parsedABI.Unpack("retrieve", rawBytes)
+	if err != nil {
+		t.Fatalf("failed to decode retrieve() result: %v", err)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("expected 1 return value, got %d", len(decoded))
+	}
+	got, ok := decoded[0].(*big.Int)
+	if !ok {
+		t.Fatalf("expected retrieve() to return *big.Int, got %T", decoded[0])
+	}
+	if got.Int64() != 7 {
+		t.Fatalf("expected retrieve() to return 7, got %s", got.String())
+	}
+}
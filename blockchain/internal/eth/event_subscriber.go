@@ -0,0 +1,226 @@
+package eth
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ringBufferCapacity bounds how many undelivered logs an EventSubscriber
+// holds for a slow consumer before it starts overwriting the oldest ones.
+const ringBufferCapacity = 256
+
+// pollFallbackInterval is how often EventSubscriber re-polls FilterLogs
+// when no websocket endpoint is configured for live subscriptions.
+const pollFallbackInterval = 5 * time.Second
+
+// reconnectBaseDelay and reconnectMaxDelay bound the exponential backoff
+// used to re-establish a dropped live subscription.
+const (
+	reconnectBaseDelay = 1 * time.Second
+	reconnectMaxDelay  = 30 * time.Second
+)
+
+// ringBuffer is a fixed-capacity FIFO that overwrites its oldest entry once
+// full, so a slow consumer on the other end of a WebSocket cannot make an
+// EventSubscriber's memory grow without bound.
+type ringBuffer struct {
+	mu     sync.Mutex
+	items  []types.Log
+	notify chan struct{}
+	cap    int
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{cap: capacity, notify: make(chan struct{}, 1)}
+}
+
+func (r *ringBuffer) push(item types.Log) {
+	r.mu.Lock()
+	r.items = append(r.items, item)
+	if len(r.items) > r.cap {
+		r.items = r.items[len(r.items)-r.cap:]
+	}
+	r.mu.Unlock()
+
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (r *ringBuffer) drain() []types.Log {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := r.items
+	r.items = nil
+	return out
+}
+
+// EventSubscriber streams logs matching a filter query to consumers that
+// call Drain, preferring a live ethclient.SubscribeFilterLogs subscription
+// (which requires a ws:// or wss:// endpoint) and transparently downgrading
+// to polling FilterLogs on an interval when only HTTP endpoints are
+// configured. A dropped live subscription is retried with exponential
+// backoff rather than ending the stream.
+type EventSubscriber struct {
+	client *Client
+	query  ethereum.FilterQuery
+	buffer *ringBuffer
+}
+
+// NewEventSubscriber creates a subscriber for query against client's pool.
+func NewEventSubscriber(client *Client, query ethereum.FilterQuery) *EventSubscriber {
+	return &EventSubscriber{
+		client: client,
+		query:  query,
+		buffer: newRingBuffer(ringBufferCapacity),
+	}
+}
+
+// Run starts feeding the subscriber's ring buffer until ctx is cancelled.
+// Call Drain (optionally after waiting on Notify) to retrieve buffered logs.
+func (s *EventSubscriber) Run(ctx context.Context) {
+	go s.run(ctx)
+}
+
+// Notify returns a channel that receives a value whenever new logs may be
+// available to Drain. It is not guaranteed to fire exactly once per log.
+func (s *EventSubscriber) Notify() <-chan struct{} {
+	return s.buffer.notify
+}
+
+// Drain returns and clears every log buffered since the last Drain call.
+func (s *EventSubscriber) Drain() []types.Log {
+	return s.buffer.drain()
+}
+
+func (s *EventSubscriber) run(ctx context.Context) {
+	wsClient, ok := s.wsEndpointClient()
+	if !ok {
+		log.Printf("eth: no websocket endpoint configured for live subscriptions; falling back to polling FilterLogs every %s", pollFallbackInterval)
+		s.pollLoop(ctx)
+		return
+	}
+	s.subscribeLoop(ctx, wsClient)
+}
+
+// wsEndpointClient returns the first pool endpoint dialed over ws:// or
+// wss://, since only those support SubscribeFilterLogs.
+func (s *EventSubscriber) wsEndpointClient() (*endpoint, bool) {
+	for _, ep := range s.client.endpoints {
+		if strings.HasPrefix(ep.url, "ws://") || strings.HasPrefix(ep.url, "wss://") {
+			return ep, true
+		}
+	}
+	return nil, false
+}
+
+// subscribeLoop maintains a live SubscribeFilterLogs subscription,
+// reconnecting with exponential backoff whenever it drops.
+func (s *EventSubscriber) subscribeLoop(ctx context.Context, ep *endpoint) {
+	delay := reconnectBaseDelay
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		logs := make(chan types.Log, 16)
+		sub, err := ep.client.SubscribeFilterLogs(ctx, s.query, logs)
+		if err != nil {
+			log.Printf("eth: failed to subscribe to logs on %s: %v", ep.url, err)
+			if !sleepOrDone(ctx, delay) {
+				return
+			}
+			delay = nextBackoff(delay)
+			continue
+		}
+
+		delay = reconnectBaseDelay // reset once a subscription succeeds
+		if !s.drainSubscription(ctx, sub, logs) {
+			return
+		}
+		if !sleepOrDone(ctx, delay) {
+			return
+		}
+	}
+}
+
+// drainSubscription forwards logs into the ring buffer until the
+// subscription errors out or ctx is cancelled. Returns false if the caller
+// should stop entirely (ctx cancelled), true if it should reconnect.
+func (s *EventSubscriber) drainSubscription(ctx context.Context, sub ethereum.Subscription, logs chan types.Log) bool {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case err := <-sub.Err():
+			if err != nil {
+				log.Printf("eth: log subscription dropped, reconnecting: %v", err)
+			}
+			return true
+		case logEntry := <-logs:
+			s.buffer.push(logEntry)
+		}
+	}
+}
+
+// pollLoop re-runs FilterLogs on an interval, advancing the from-block
+// cursor so each poll only asks for newly produced logs.
+func (s *EventSubscriber) pollLoop(ctx context.Context) {
+	ticker := time.NewTicker(pollFallbackInterval)
+	defer ticker.Stop()
+
+	from := s.query.FromBlock
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			query := s.query
+			query.FromBlock = from
+			query.ToBlock = nil
+
+			logs, err := s.client.FilterLogs(ctx, query.Addresses[0], query.Topics, query.FromBlock, query.ToBlock)
+			if err != nil {
+				log.Printf("eth: polling fallback FilterLogs failed: %v", err)
+				continue
+			}
+			for _, logEntry := range logs {
+				s.buffer.push(logEntry)
+				next := new(big.Int).SetUint64(logEntry.BlockNumber + 1)
+				if from == nil || next.Cmp(from) > 0 {
+					from = next
+				}
+			}
+		}
+	}
+}
+
+func nextBackoff(delay time.Duration) time.Duration {
+	next := delay * 2
+	if next > reconnectMaxDelay {
+		return reconnectMaxDelay
+	}
+	return next
+}
+
+// sleepOrDone waits for delay or ctx cancellation, returning false if
+// cancelled so callers can stop retrying.
+func sleepOrDone(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
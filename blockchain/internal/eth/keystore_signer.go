@@ -0,0 +1,58 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// keystoreScryptN and keystoreScryptP match go-ethereum's standard
+// interactive scrypt parameters, used only to open existing keystore
+// directories (no new keys are generated here).
+const (
+	keystoreScryptN = keystore.StandardScryptN
+	keystoreScryptP = keystore.StandardScryptP
+)
+
+// KeystoreSigner signs transactions using a go-ethereum encrypted JSON
+// keyfile, unlocked once at startup with a passphrase.
+type KeystoreSigner struct {
+	ks      *keystore.KeyStore
+	account accounts.Account
+}
+
+// NewKeystoreSigner opens the keystore directory at keystoreDir, locates
+// the account matching address, and unlocks it with passphrase. The
+// account remains unlocked for the lifetime of the process.
+func NewKeystoreSigner(keystoreDir string, address common.Address, passphrase string) (*KeystoreSigner, error) {
+	ks := keystore.NewKeyStore(keystoreDir, keystoreScryptN, keystoreScryptP)
+
+	account, err := ks.Find(accounts.Account{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account %s in keystore %s: %v", address, keystoreDir, err)
+	}
+
+	if err := ks.Unlock(account, passphrase); err != nil {
+		return nil, fmt.Errorf("failed to unlock account %s: %v", address, err)
+	}
+
+	return &KeystoreSigner{ks: ks, account: account}, nil
+}
+
+// Address implements Signer.
+func (s *KeystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+// SignTx implements Signer.
+func (s *KeystoreSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := s.ks.SignTx(s.account, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("keystore signing failed: %v", err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,69 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PlaintextEnvSigner signs with a private key read verbatim from the
+// ETH_PRIVATE_KEY environment variable. This is only suitable for local
+// development and tests; it is refused unless the caller explicitly opts
+// in, since the key otherwise sits unencrypted in process environment.
+type PlaintextEnvSigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewPlaintextEnvSigner loads ETH_PRIVATE_KEY and returns a signer backed
+// by it. allowInsecure must be true (set via the --allow-insecure-key
+// flag) or this returns an error instead of silently signing with a
+// plaintext key.
+func NewPlaintextEnvSigner(allowInsecure bool) (*PlaintextEnvSigner, error) {
+	if !allowInsecure {
+		return nil, errors.New("plaintext env key signer requires --allow-insecure-key; use a keystore or clef signer in production")
+	}
+
+	privateKeyHex := os.Getenv("ETH_PRIVATE_KEY")
+	if privateKeyHex == "" {
+		return nil, errors.New("ETH_PRIVATE_KEY environment variable not set")
+	}
+	privateKeyHex = strings.TrimPrefix(privateKeyHex, "0x")
+
+	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+
+	publicKeyECDSA, ok := privateKey.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("error casting public key to ECDSA")
+	}
+
+	return &PlaintextEnvSigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(*publicKeyECDSA),
+	}, nil
+}
+
+// Address implements Signer.
+func (s *PlaintextEnvSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx implements Signer. It uses the latest signer for chainID so both
+// legacy and EIP-1559 dynamic fee transactions are signed correctly.
+func (s *PlaintextEnvSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), s.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,21 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Signer produces signed transactions without exposing the underlying key
+// material to callers. DeployContract and EstimateDeploymentCost depend on
+// this interface rather than reading a private key directly, so the
+// signing backend (local keystore, remote clef instance, or a plaintext
+// env key for local development) can be swapped without touching
+// deployment logic.
+type Signer interface {
+	// Address returns the account this signer signs transactions from.
+	Address() common.Address
+	// SignTx returns tx signed for the given chain ID.
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
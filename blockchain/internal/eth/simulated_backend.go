@@ -0,0 +1,86 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	scitypes "github.com/yourusername/smart-contract-intelligence/pkg/types"
+)
+
+// simulatedGasLimit is the per-block gas limit the simulated chain is
+// seeded with, comfortably above anything a single contract deployment or
+// call in this service needs.
+const simulatedGasLimit = 30_000_000
+
+// simulatedPrefundedETH is how much ETH each simulated account starts with,
+// far more than any dry-run could plausibly spend.
+var simulatedPrefundedETH = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+// SimulatedBackend is an in-process Backend, following the pattern of
+// go-ethereum's own bind/backends.SimulatedBackend, that mines a new block
+// for every submitted transaction instead of talking to a remote node. It
+// lets DryRunDeploy, and unit tests for DeployContract, InteractWithContract,
+// and constructor-argument encoding, exercise the exact same signing and
+// gas-estimation code paths as a real deployment without spending real ETH
+// or depending on network access.
+type SimulatedBackend struct {
+	*backends.SimulatedBackend
+}
+
+// NewSimulatedBackend boots a fresh simulated chain whose genesis prefunds
+// each of accounts with simulatedPrefundedETH.
+func NewSimulatedBackend(accounts ...common.Address) *SimulatedBackend {
+	alloc := core.GenesisAlloc{}
+	for _, account := range accounts {
+		alloc[account] = core.GenesisAccount{Balance: simulatedPrefundedETH}
+	}
+	return &SimulatedBackend{SimulatedBackend: backends.NewSimulatedBackend(alloc, simulatedGasLimit)}
+}
+
+// SendTransaction submits tx and immediately mines a block containing it, so
+// its receipt is available right away rather than requiring a caller to wait
+// or poll as they would against a real chain.
+func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	b.SimulatedBackend.Commit()
+	return nil
+}
+
+var _ Backend = (*SimulatedBackend)(nil)
+
+// DryRunDeploy runs a full deployment of req against an in-process simulated
+// chain seeded with the configured signer's account, so a caller can catch
+// reverts and see the exact gas a deployment will use before spending real
+// ETH. It shares DeployContract's signing, encoding, and gas-estimation
+// logic, so a dry run only diverges from a real deployment in where the
+// transaction actually lands.
+func (c *Client) DryRunDeploy(ctx context.Context, req *scitypes.DeploymentRequest) (*DeploymentResult, error) {
+	if c.signer == nil {
+		return nil, errors.New("no signer configured")
+	}
+
+	sim := NewSimulatedBackend(c.signer.Address())
+	chainID, err := sim.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get simulated chain ID: %v", err)
+	}
+
+	simClient := &Client{
+		endpoints: []*endpoint{{url: "simulated", client: sim, healthy: 1}},
+		chainID:   chainID,
+		signer:    c.signer,
+	}
+	// Sign for the simulated chain, whatever chain req itself targets.
+	simReq := *req
+	simReq.ChainID = int(chainID.Int64())
+	return simClient.DeployContract(ctx, &simReq)
+}
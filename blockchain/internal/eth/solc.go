@@ -0,0 +1,348 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultSolcVersion pins the compiler version used when a deployment
+// doesn't specify one, so two deploys of identical source a month apart
+// still produce identical bytecode. Override with ETH_SOLC_VERSION.
+const DefaultSolcVersion = "v0.8.24+commit.e11b9ed9"
+
+// solcBinBaseURL is solc-bin, the canonical distribution point for
+// prebuilt solc binaries, keyed by platform and exact version string.
+const solcBinBaseURL = "https://binaries.soliditylang.org"
+
+// SolcInput is the subset of solc's Standard JSON compiler input this
+// service drives. See
+// https://docs.soliditylang.org/en/latest/using-the-compiler.html#compiler-input-and-output-json-description.
+type SolcInput struct {
+	// CompilerVersion selects which solc binary compiles this input, e.g.
+	// "v0.8.24+commit.e11b9ed9". Defaults to DefaultSolcVersion when empty.
+	CompilerVersion string `json:"-"`
+
+	Language string                `json:"language"`
+	Sources  map[string]SolcSource `json:"sources"`
+	Settings SolcSettings          `json:"settings"`
+}
+
+// SolcSource is one entry in SolcInput.Sources.
+type SolcSource struct {
+	Content string `json:"content"`
+}
+
+// SolcSettings is the "settings" object of Standard JSON input.
+type SolcSettings struct {
+	Remappings      []string                        `json:"remappings,omitempty"`
+	EVMVersion      string                           `json:"evmVersion,omitempty"`
+	Optimizer       SolcOptimizerSettings            `json:"optimizer"`
+	OutputSelection map[string]map[string][]string   `json:"outputSelection"`
+}
+
+// SolcOptimizerSettings is the "settings.optimizer" object.
+type SolcOptimizerSettings struct {
+	Enabled bool `json:"enabled"`
+	Runs    int  `json:"runs"`
+}
+
+// CompiledContract is one contract produced by a Standard JSON compile.
+type CompiledContract struct {
+	Name   string
+	ABI    json.RawMessage
+	// Bytecode is the creation (init) code: what's sent as a CREATE
+	// transaction's data, including the appended constructor call.
+	Bytecode []byte
+	// DeployedBytecode is the contract's runtime code, i.e. what CodeAt
+	// returns once the constructor has run.
+	DeployedBytecode []byte
+}
+
+// DefaultSolcInput builds a single-file Standard JSON input for
+// sourceCode, requesting exactly the outputs this service needs (ABI,
+// creation bytecode, and deployed/runtime bytecode) with the optimizer on
+// at the commonly used 200 runs.
+func DefaultSolcInput(fileName, sourceCode, version string) SolcInput {
+	if version == "" {
+		version = DefaultSolcVersion
+	}
+	return SolcInput{
+		CompilerVersion: version,
+		Language:        "Solidity",
+		Sources: map[string]SolcSource{
+			fileName: {Content: sourceCode},
+		},
+		Settings: SolcSettings{
+			Optimizer: SolcOptimizerSettings{Enabled: true, Runs: 200},
+			OutputSelection: map[string]map[string][]string{
+				"*": {"*": {"abi", "evm.bytecode.object", "evm.deployedBytecode.object"}},
+			},
+		},
+	}
+}
+
+// solcStandardJSONOutput is the subset of Standard JSON compiler output
+// this service reads.
+type solcStandardJSONOutput struct {
+	Errors []struct {
+		Severity         string `json:"severity"`
+		Message          string `json:"message"`
+		FormattedMessage string `json:"formattedMessage"`
+	} `json:"errors"`
+	Contracts map[string]map[string]struct {
+		Abi json.RawMessage `json:"abi"`
+		Evm struct {
+			Bytecode struct {
+				Object string `json:"object"`
+			} `json:"bytecode"`
+			DeployedBytecode struct {
+				Object string `json:"object"`
+			} `json:"deployedBytecode"`
+		} `json:"evm"`
+	} `json:"contracts"`
+}
+
+// CompileStandardJSON compiles input with the pinned solc version it
+// names, returning every contract found keyed "<file>:<contract>", along
+// with the exact raw Standard JSON input bytes sent to the compiler so a
+// caller can persist it for bit-for-bit reproducible verification later.
+func (c *Client) CompileStandardJSON(ctx context.Context, input SolcInput) (map[string]*CompiledContract, []byte, error) {
+	version := input.CompilerVersion
+	if version == "" {
+		version = DefaultSolcVersion
+	}
+
+	rawInput, err := json.Marshal(input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal standard-json input: %v", err)
+	}
+
+	rawOutput, err := runSolc(ctx, version, rawInput)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var output solcStandardJSONOutput
+	if err := json.Unmarshal(rawOutput, &output); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse solc output: %v", err)
+	}
+	for _, e := range output.Errors {
+		if e.Severity == "error" {
+			return nil, nil, fmt.Errorf("solc compilation failed: %s", e.FormattedMessage)
+		}
+	}
+
+	contracts := make(map[string]*CompiledContract)
+	for file, fileContracts := range output.Contracts {
+		for name, contract := range fileContracts {
+			bytecode, err := hexDecode(contract.Evm.Bytecode.Object)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decode creation bytecode for %s:%s: %v", file, name, err)
+			}
+			deployedBytecode, err := hexDecode(contract.Evm.DeployedBytecode.Object)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decode deployed bytecode for %s:%s: %v", file, name, err)
+			}
+			contracts[fmt.Sprintf("%s:%s", file, name)] = &CompiledContract{
+				Name:             name,
+				ABI:              contract.Abi,
+				Bytecode:         bytecode,
+				DeployedBytecode: deployedBytecode,
+			}
+		}
+	}
+	return contracts, rawInput, nil
+}
+
+// runSolc invokes the pinned solc binary (downloading it to the local
+// cache on first use) in --standard-json mode, falling back to running it
+// via the official `ethereum/solc:<version>` Docker image when
+// ETH_SOLC_USE_DOCKER is set or no local binary could be resolved.
+func runSolc(ctx context.Context, version string, input []byte) ([]byte, error) {
+	if os.Getenv("ETH_SOLC_USE_DOCKER") == "" {
+		if binPath, err := resolveSolcBinary(version); err == nil {
+			return execSolc(ctx, binPath, nil, input)
+		}
+	}
+	return execSolc(ctx, "docker", []string{"run", "--rm", "-i", fmt.Sprintf("ethereum/solc:%s", solcDockerTag(version))}, input)
+}
+
+// solcDockerTag maps a solc-bin version string, e.g.
+// "v0.8.24+commit.e11b9ed9", to the bare "0.8.24" tag the ethereum/solc
+// Docker image is actually published under. Docker tag syntax disallows
+// "+", so the solc-bin version string itself is never a valid tag.
+func solcDockerTag(version string) string {
+	tag := strings.TrimPrefix(version, "v")
+	if i := strings.IndexByte(tag, '+'); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+func execSolc(ctx context.Context, bin string, preArgs []string, input []byte) ([]byte, error) {
+	args := append(append([]string{}, preArgs...), "--standard-json")
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("solc invocation failed: %v (stderr: %s)", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// resolveSolcBinary returns the path to a pinned solc binary for version,
+// downloading it from solc-bin into a local cache directory on first use.
+func resolveSolcBinary(version string) (string, error) {
+	cacheDir := solcCacheDir()
+	binPath := filepath.Join(cacheDir, version, solcBinaryName())
+	if _, err := os.Stat(binPath); err == nil {
+		return binPath, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(binPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create solc cache dir: %v", err)
+	}
+	if err := downloadSolcBinary(version, binPath); err != nil {
+		return "", err
+	}
+	return binPath, nil
+}
+
+func solcCacheDir() string {
+	if dir := os.Getenv("ETH_SOLC_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "solc-cache")
+}
+
+// solcBinaryName maps the host platform to the filename solc-bin publishes
+// its release list under (see https://binaries.soliditylang.org/<platform>/list.json).
+func solcBinaryName() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "solc.exe"
+	default:
+		return "solc"
+	}
+}
+
+func solcPlatformDir() string {
+	switch runtime.GOOS {
+	case "linux":
+		return "linux-amd64"
+	case "darwin":
+		return "macosx-amd64"
+	case "windows":
+		return "windows-amd64"
+	default:
+		return "linux-amd64"
+	}
+}
+
+// solcListing is the subset of a solc-bin platform's list.json this service
+// reads: the published sha256 of every build, keyed by its filename.
+type solcListing struct {
+	Builds []struct {
+		Path   string `json:"path"`
+		SHA256 string `json:"sha256"`
+	} `json:"builds"`
+}
+
+// solcBuildChecksum fetches platform's list.json from solc-bin and returns
+// the published sha256 for filename, so a downloaded binary can be verified
+// before it's ever executed.
+func solcBuildChecksum(platform, filename string) (string, error) {
+	url := fmt.Sprintf("%s/%s/list.json", solcBinBaseURL, platform)
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch solc release list: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch solc release list: unexpected status %s", resp.Status)
+	}
+
+	var listing solcListing
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return "", fmt.Errorf("failed to parse solc release list: %v", err)
+	}
+	for _, build := range listing.Builds {
+		if build.Path == filename {
+			return strings.TrimPrefix(build.SHA256, "0x"), nil
+		}
+	}
+	return "", fmt.Errorf("solc release list has no entry for %s", filename)
+}
+
+// downloadSolcBinary fetches the solc release for version from solc-bin,
+// verifies it against the sha256 solc-bin's own list.json publishes for it,
+// and only then writes it, executable, to destPath. This is the supply-chain
+// check that justifies this whole pinned-version, Standard JSON compilation
+// path: an unverified binary fetched over HTTP and then executed on every
+// compile would undermine the deterministic, trustworthy verification this
+// service otherwise provides.
+func downloadSolcBinary(version, destPath string) error {
+	platform := solcPlatformDir()
+	filename := fmt.Sprintf("solc-%s-%s", platform, version)
+
+	wantChecksum, err := solcBuildChecksum(platform, filename)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", solcBinBaseURL, platform, filename)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download solc %s: %v", version, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download solc %s: unexpected status %s", version, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read solc binary: %v", err)
+	}
+
+	gotChecksum := sha256.Sum256(body)
+	if hex.EncodeToString(gotChecksum[:]) != strings.ToLower(wantChecksum) {
+		return fmt.Errorf("solc %s failed checksum verification: downloaded binary does not match solc-bin's published sha256", version)
+	}
+
+	out, err := os.OpenFile(destPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create solc binary file: %v", err)
+	}
+	defer out.Close()
+
+	if _, err := out.Write(body); err != nil {
+		return fmt.Errorf("failed to write solc binary: %v", err)
+	}
+	return nil
+}
+
+func hexDecode(s string) ([]byte, error) {
+	s = strings.TrimPrefix(s, "0x")
+	if s == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(s)
+}
@@ -0,0 +1,175 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// VerificationResult is the outcome of attempting to match a deployed
+// contract against recompiled source.
+type VerificationResult struct {
+	Matched bool
+	// ContractName is the "<file>:<contract>" key of the matching contract
+	// in the recompiled source, set only when Matched is true.
+	ContractName string
+	// ConstructorArgsMatch is true when ConstructorArguments was supplied
+	// and the bytes recovered from the creation transaction encode the
+	// same values.
+	ConstructorArgsMatch bool
+	// SolcInput is the exact Standard JSON input that was compiled, so a
+	// caller can persist it for bit-for-bit reproducible verification.
+	SolcInput []byte
+}
+
+// VerifyContractSource recompiles sourceCode and checks whether it produced
+// the bytecode deployed at contractAddress.
+//
+// A naive byte comparison of deployed vs. freshly compiled bytecode almost
+// always fails even for an exact source match, because solc appends a CBOR
+// metadata hash (an IPFS/Swarm content hash of the source plus compiler
+// settings) to the end of the runtime bytecode that isn't guaranteed to
+// reproduce across environments. This strips that trailing hash from both
+// sides before comparing.
+//
+// Matching is also unable to rely on a bytecode prefix check once
+// constructor arguments are involved, since CodeAt only ever returns the
+// contract's post-constructor runtime bytecode - the original init code
+// (bytecode + encoded constructor args) only exists in the creation
+// transaction's input data. creationTxHash identifies that transaction so
+// the constructor arguments actually used can be recovered by diffing it
+// against the compiled creation bytecode; pass "" to skip constructor
+// argument recovery and rely on runtime bytecode matching alone.
+// constructorArgs, if non-empty, is the same JSON array of values accepted
+// by DeploymentRequest.ConstructorArguments, checked against what was
+// recovered.
+func (c *Client) VerifyContractSource(
+	ctx context.Context,
+	contractAddress string,
+	creationTxHash string,
+	sourceCode string,
+	constructorArgs string,
+) (*VerificationResult, error) {
+	contracts, rawInput, err := c.CompileContract(sourceCode)
+	if err != nil {
+		return nil, fmt.Errorf("compilation failed: %v", err)
+	}
+	if len(contracts) == 0 {
+		return nil, errors.New("no contracts found in source code")
+	}
+
+	address := common.HexToAddress(contractAddress)
+	var deployedBytecode []byte
+	if err := c.do(func(ec Backend) error {
+		code, err := ec.CodeAt(ctx, address, nil)
+		if err != nil {
+			return err
+		}
+		deployedBytecode = code
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get deployed bytecode: %v", err)
+	}
+	if len(deployedBytecode) == 0 {
+		return nil, fmt.Errorf("no code deployed at %s", contractAddress)
+	}
+	deployedRuntime := stripMetadataHash(deployedBytecode)
+
+	var creationInput []byte
+	if creationTxHash != "" {
+		hash := common.HexToHash(creationTxHash)
+		if err := c.do(func(ec Backend) error {
+			tx, _, err := ec.TransactionByHash(ctx, hash)
+			if err != nil {
+				return err
+			}
+			creationInput = tx.Data()
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to get creation transaction: %v", err)
+		}
+	}
+
+	result := &VerificationResult{SolcInput: rawInput}
+	for name, contract := range contracts {
+		if !bytes.Equal(deployedRuntime, stripMetadataHash(contract.DeployedBytecode)) {
+			continue
+		}
+
+		result.Matched = true
+		result.ContractName = name
+
+		if creationInput != nil && constructorArgs != "" {
+			recoveredArgs, err := recoverConstructorArgs(creationInput, contract.Bytecode)
+			if err != nil {
+				return nil, fmt.Errorf("matched bytecode for %s but failed to recover constructor arguments: %v", name, err)
+			}
+			expectedArgs, err := encodeConstructorArgs(contract.ABI, constructorArgs)
+			if err != nil {
+				return nil, fmt.Errorf("matched bytecode for %s but failed to encode expected constructor arguments: %v", name, err)
+			}
+			result.ConstructorArgsMatch = bytes.Equal(recoveredArgs, expectedArgs)
+		}
+		break
+	}
+
+	return result, nil
+}
+
+// stripMetadataHash removes solc's trailing CBOR-encoded metadata hash from
+// bytecode, if present. The last two bytes of solc output encode the CBOR
+// blob's length in big-endian; everything from there to the end (plus
+// those two length bytes) is the metadata, not executable code, and
+// differs across otherwise-identical compiles whenever the embedded IPFS
+// hash or compiler version changes. Older solc builds without metadata, or
+// bytecode too short to contain any, are returned unchanged.
+func stripMetadataHash(bytecode []byte) []byte {
+	const lengthFieldSize = 2
+	if len(bytecode) < lengthFieldSize {
+		return bytecode
+	}
+
+	metadataLen := int(binary.BigEndian.Uint16(bytecode[len(bytecode)-lengthFieldSize:]))
+	total := metadataLen + lengthFieldSize
+	if metadataLen <= 0 || total >= len(bytecode) {
+		return bytecode
+	}
+	return bytecode[:len(bytecode)-total]
+}
+
+// recoverConstructorArgs returns the constructor argument bytes actually
+// used to deploy a contract, by stripping the compiled creation bytecode
+// prefix from the creation transaction's full input data. The remainder is
+// whatever abi.Pack("", args...) produced at deploy time.
+func recoverConstructorArgs(creationInput, compiledCreationBytecode []byte) ([]byte, error) {
+	if !bytes.HasPrefix(creationInput, compiledCreationBytecode) {
+		return nil, errors.New("creation transaction input does not start with the compiled creation bytecode")
+	}
+	return creationInput[len(compiledCreationBytecode):], nil
+}
+
+// encodeConstructorArgs ABI-encodes a JSON array of constructor argument
+// values the same way DeployContract does, so the result can be compared
+// against what recoverConstructorArgs found on chain.
+func encodeConstructorArgs(contractABI json.RawMessage, constructorArgsJSON string) ([]byte, error) {
+	var args []interface{}
+	if err := json.Unmarshal([]byte(constructorArgsJSON), &args); err != nil {
+		return nil, fmt.Errorf("failed to parse constructor arguments: %v", err)
+	}
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(string(contractABI)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+	return parsedABI.Pack("", args...)
+}
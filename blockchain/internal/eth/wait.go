@@ -0,0 +1,284 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// defaultPollInterval, defaultPollBackoff, and defaultMaxPollInterval bound
+// WaitDeployed's polling of a single endpoint when the caller doesn't
+// override them via WaitOptions.
+const (
+	defaultPollInterval    = 2 * time.Second
+	defaultPollBackoff     = 1.5
+	defaultMaxPollInterval = 30 * time.Second
+)
+
+// ConfirmationPolicy selects how long WaitDeployed waits, past the receipt
+// itself, before considering a deployment final. A zero value is a single
+// confirmation (the receipt's own block).
+type ConfirmationPolicy struct {
+	// N is the fixed confirmation count to require; the receipt's own block
+	// counts as the first. Ignored once Tag is set.
+	N uint64
+	// Tag selects a Merge-aware finality tag ("safe" or "finalized") instead
+	// of a fixed count: WaitDeployed is satisfied once that tagged block
+	// (queried via HeaderByNumber) reaches the receipt's block number.
+	Tag string
+}
+
+// Confirmations is a fixed-N ConfirmationPolicy.
+func Confirmations(n uint64) ConfirmationPolicy {
+	return ConfirmationPolicy{N: n}
+}
+
+// SafeConfirmations waits for the chain's "safe" block to reach the
+// deployment's block.
+func SafeConfirmations() ConfirmationPolicy {
+	return ConfirmationPolicy{Tag: "safe"}
+}
+
+// FinalizedConfirmations waits for the chain's "finalized" block to reach
+// the deployment's block.
+func FinalizedConfirmations() ConfirmationPolicy {
+	return ConfirmationPolicy{Tag: "finalized"}
+}
+
+// blockNumberArg returns the HeaderByNumber argument for a tagged policy.
+func (p ConfirmationPolicy) blockNumberArg() (*big.Int, error) {
+	switch p.Tag {
+	case "safe":
+		return big.NewInt(rpc.SafeBlockNumber.Int64()), nil
+	case "finalized":
+		return big.NewInt(rpc.FinalizedBlockNumber.Int64()), nil
+	default:
+		return nil, fmt.Errorf("unknown confirmation tag %q", p.Tag)
+	}
+}
+
+// WaitOptions configures WaitDeployed's polling.
+type WaitOptions struct {
+	// PollInterval is the initial delay between polls of the receipt, code,
+	// and confirmation checks. Defaults to defaultPollInterval.
+	PollInterval time.Duration
+	// PollBackoff multiplies PollInterval after every unsatisfied poll, up to
+	// MaxPollInterval. A non-zero value <= 1 disables backoff. Defaults to
+	// defaultPollBackoff when zero.
+	PollBackoff float64
+	// MaxPollInterval caps the backoff. Defaults to defaultMaxPollInterval.
+	MaxPollInterval time.Duration
+	// Confirmations is the policy to wait for after the receipt and its code
+	// are confirmed. Defaults to Confirmations(1).
+	Confirmations ConfirmationPolicy
+}
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaultPollInterval
+	}
+	if o.PollBackoff == 0 {
+		o.PollBackoff = defaultPollBackoff
+	}
+	if o.MaxPollInterval <= 0 {
+		o.MaxPollInterval = defaultMaxPollInterval
+	}
+	if o.Confirmations == (ConfirmationPolicy{}) {
+		o.Confirmations = Confirmations(1)
+	}
+	return o
+}
+
+// nextPollInterval applies opts' backoff to the current interval, capped at
+// MaxPollInterval.
+func nextPollInterval(current time.Duration, opts WaitOptions) time.Duration {
+	if opts.PollBackoff <= 1 {
+		return current
+	}
+	next := time.Duration(float64(current) * opts.PollBackoff)
+	if next > opts.MaxPollInterval {
+		return opts.MaxPollInterval
+	}
+	return next
+}
+
+// WaitDeployed polls for txHash's receipt, confirms the deployed contract
+// actually has code on chain, waits for opts.Confirmations to be satisfied,
+// and returns the same DeploymentResult shape DeployContract does. Unlike
+// DeployContract's prior use of bind.WaitMined, which gave up and returned
+// only a tx hash on timeout, WaitDeployed is meant to be resumable: a caller
+// that only has a tx hash (e.g. after a service restart) can call this, or
+// the /deploy/:txHash/await endpoint, to pick tracking back up with no other
+// state.
+func (c *Client) WaitDeployed(ctx context.Context, txHash string, opts WaitOptions) (*DeploymentResult, error) {
+	opts = opts.withDefaults()
+	hash := common.HexToHash(txHash)
+
+	receipt, err := c.pollReceipt(ctx, hash, opts)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return nil, fmt.Errorf("deployment transaction %s reverted", hash.Hex())
+	}
+
+	if receipt.ContractAddress != (common.Address{}) {
+		if err := c.pollCode(ctx, receipt.ContractAddress, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.pollConfirmations(ctx, receipt, opts); err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	if err := c.do(func(ec Backend) error {
+		t, _, err := ec.TransactionByHash(ctx, hash)
+		if err != nil {
+			return err
+		}
+		tx = t
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction: %v", err)
+	}
+
+	effectiveGasPrice := receipt.EffectiveGasPrice
+	if effectiveGasPrice == nil {
+		effectiveGasPrice = tx.GasPrice()
+	}
+	gasCost := new(big.Float).Mul(
+		new(big.Float).SetInt(effectiveGasPrice),
+		new(big.Float).SetUint64(receipt.GasUsed),
+	)
+	weiPerEth := new(big.Float).SetInt(big.NewInt(1e18))
+	costInEth, _ := new(big.Float).Quo(gasCost, weiPerEth).Float64()
+
+	result := &DeploymentResult{
+		TxHash:          hash.Hex(),
+		ContractAddress: receipt.ContractAddress.Hex(),
+		BlockNumber:     int(receipt.BlockNumber.Int64()),
+		GasUsed:         receipt.GasUsed,
+		GasPrice:        effectiveGasPrice,
+		Cost:            costInEth,
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.MaxFeePerGas = tx.GasFeeCap()
+		result.MaxPriorityFeePerGas = tx.GasTipCap()
+	}
+	return result, nil
+}
+
+// pollReceipt polls TransactionReceipt, backing off between attempts per
+// opts, until it is available or ctx is done.
+func (c *Client) pollReceipt(ctx context.Context, hash common.Hash, opts WaitOptions) (*types.Receipt, error) {
+	interval := opts.PollInterval
+	for {
+		var receipt *types.Receipt
+		err := c.do(func(ec Backend) error {
+			r, err := ec.TransactionReceipt(ctx, hash)
+			if err != nil {
+				return err
+			}
+			receipt = r
+			return nil
+		})
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get transaction receipt: %v", err)
+		}
+
+		if !sleepOrDone(ctx, interval) {
+			return nil, ctx.Err()
+		}
+		interval = nextPollInterval(interval, opts)
+	}
+}
+
+// pollCode polls CodeAt until the deployed contract has non-empty code,
+// mirroring go-ethereum's bind.WaitDeployed / ErrNoCodeAfterDeploy check: a
+// mined receipt with a contract address doesn't guarantee the code is
+// actually visible yet on whichever endpoint serves the next read.
+func (c *Client) pollCode(ctx context.Context, address common.Address, opts WaitOptions) error {
+	interval := opts.PollInterval
+	for {
+		var code []byte
+		err := c.do(func(ec Backend) error {
+			cd, err := ec.CodeAt(ctx, address, nil)
+			if err != nil {
+				return err
+			}
+			code = cd
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check deployed code: %v", err)
+		}
+		if len(code) > 0 {
+			return nil
+		}
+
+		if !sleepOrDone(ctx, interval) {
+			return fmt.Errorf("no contract code at %s after deployment: %w", address.Hex(), ctx.Err())
+		}
+		interval = nextPollInterval(interval, opts)
+	}
+}
+
+// pollConfirmations waits until policy is satisfied relative to receipt's
+// block: either a fixed confirmation count past it (measured against the
+// latest head), or a "safe"/"finalized" tagged block reaching it.
+func (c *Client) pollConfirmations(ctx context.Context, receipt *types.Receipt, opts WaitOptions) error {
+	policy := opts.Confirmations
+	interval := opts.PollInterval
+
+	var blockArg *big.Int
+	target := new(big.Int).Set(receipt.BlockNumber)
+	if policy.Tag == "" {
+		n := policy.N
+		if n == 0 {
+			n = 1
+		}
+		target.Add(target, big.NewInt(int64(n-1)))
+	} else {
+		arg, err := policy.blockNumberArg()
+		if err != nil {
+			return err
+		}
+		blockArg = arg
+	}
+
+	for {
+		var headNumber *big.Int
+		err := c.do(func(ec Backend) error {
+			header, err := ec.HeaderByNumber(ctx, blockArg)
+			if err != nil {
+				return err
+			}
+			headNumber = header.Number
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check confirmations: %v", err)
+		}
+
+		if headNumber.Cmp(target) >= 0 {
+			return nil
+		}
+
+		if !sleepOrDone(ctx, interval) {
+			return ctx.Err()
+		}
+		interval = nextPollInterval(interval, opts)
+	}
+}
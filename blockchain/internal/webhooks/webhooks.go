@@ -0,0 +1,321 @@
+// Package webhooks delivers outbound notifications for contract lifecycle
+// events to owner-registered HTTP endpoints, with HMAC signing, retries,
+// and a dead-letter queue for deliveries that never succeed.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/yourusername/smart-contract-intelligence/internal/auth"
+)
+
+// Event names emitted by the contracts service.
+const (
+	EventContractCreated    = "contract.created"
+	EventContractUpdated    = "contract.updated"
+	EventContractDeleted    = "contract.deleted"
+	EventContractDeployed   = "contract.deployed"
+	EventDeploymentConfirmed = "deployment.confirmed"
+	EventDeploymentFailed    = "deployment.failed"
+)
+
+const (
+	maxDeliveryAttempts = 6
+	baseRetryDelay      = 30 * time.Second
+)
+
+// Webhook is an owner-registered HTTP endpoint subscribed to a set of
+// lifecycle events.
+type Webhook struct {
+	ID        string    `bson:"_id" json:"id"`
+	OwnerID   string    `bson:"owner_id" json:"owner_id"`
+	URL       string    `bson:"url" json:"url"`
+	Secret    string    `bson:"secret" json:"-"`
+	Events    []string  `bson:"events" json:"events"`
+	Active    bool      `bson:"active" json:"active"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// envelope is the JSON body POSTed to a webhook URL.
+type envelope struct {
+	Event     string      `json:"event"`
+	ID        string      `json:"id"`
+	Timestamp time.Time   `json:"timestamp"`
+	Payload   interface{} `json:"payload"`
+}
+
+// Delivery tracks one attempted (and possibly retried) webhook delivery.
+type Delivery struct {
+	ID          string    `bson:"_id" json:"id"`
+	WebhookID   string    `bson:"webhook_id" json:"webhook_id"`
+	Event       string    `bson:"event" json:"event"`
+	Envelope    []byte    `bson:"envelope" json:"-"`
+	Attempt     int       `bson:"attempt" json:"attempt"`
+	Status      string    `bson:"status" json:"status"` // "pending", "delivered", "dead"
+	NextAttempt time.Time `bson:"next_attempt" json:"next_attempt"`
+	LastError   string    `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Service manages webhook registrations and delivery.
+type Service struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+	httpClient *http.Client
+}
+
+// NewService creates a webhook service backed by the given collections.
+func NewService(webhooks, deliveries *mongo.Collection) *Service {
+	return &Service{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// RegisterWebhook creates a new webhook subscription for the caller.
+func (s *Service) RegisterWebhook(c *gin.Context) {
+	var req struct {
+		URL    string   `json:"url" binding:"required"`
+		Secret string   `json:"secret" binding:"required"`
+		Events []string `json:"events" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ownerID, ok := auth.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	webhook := Webhook{
+		ID:        uuid.New().String(),
+		OwnerID:   ownerID,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    req.Events,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := s.webhooks.InsertOne(ctx, webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register webhook"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// ListWebhooks lists webhooks registered by the caller.
+func (s *Service) ListWebhooks(c *gin.Context) {
+	ownerID, ok := auth.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cursor, err := s.webhooks.Find(ctx, bson.M{"owner_id": ownerID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list webhooks"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var hooks []Webhook
+	if err := cursor.All(ctx, &hooks); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode webhooks"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": hooks})
+}
+
+// DeleteWebhook removes a webhook subscription owned by the caller.
+func (s *Service) DeleteWebhook(c *gin.Context) {
+	id := c.Param("id")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	requestUserID, ok := auth.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var hook Webhook
+	if err := s.webhooks.FindOne(ctx, bson.M{"_id": id}).Decode(&hook); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		return
+	}
+	if hook.OwnerID != requestUserID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you don't have permission to delete this webhook"})
+		return
+	}
+
+	result, err := s.webhooks.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete webhook"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "webhook deleted"})
+}
+
+// ReplayDelivery resets a failed or dead-lettered delivery so the worker
+// picks it up again on its next pass, provided the caller owns the webhook
+// the delivery belongs to.
+func (s *Service) ReplayDelivery(c *gin.Context) {
+	id := c.Param("id")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	requestUserID, ok := auth.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var delivery Delivery
+	if err := s.deliveries.FindOne(ctx, bson.M{"_id": id}).Decode(&delivery); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "delivery not found"})
+		return
+	}
+	var hook Webhook
+	if err := s.webhooks.FindOne(ctx, bson.M{"_id": delivery.WebhookID}).Decode(&hook); err != nil || hook.OwnerID != requestUserID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you don't have permission to replay this delivery"})
+		return
+	}
+
+	result, err := s.deliveries.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":       "pending",
+			"attempt":      0,
+			"next_attempt": time.Now(),
+			"last_error":   "",
+		}},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to schedule replay"})
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "delivery not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "delivery scheduled for replay"})
+}
+
+// ListDeadLetters lists deliveries that exhausted their retry budget for
+// webhooks owned by the caller.
+func (s *Service) ListDeadLetters(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	requestUserID, ok := auth.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	hookCursor, err := s.webhooks.Find(ctx, bson.M{"owner_id": requestUserID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list webhooks"})
+		return
+	}
+	defer hookCursor.Close(ctx)
+
+	var hooks []Webhook
+	if err := hookCursor.All(ctx, &hooks); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode webhooks"})
+		return
+	}
+	hookIDs := make([]string, len(hooks))
+	for i, hook := range hooks {
+		hookIDs[i] = hook.ID
+	}
+
+	cursor, err := s.deliveries.Find(ctx, bson.M{"status": "dead", "webhook_id": bson.M{"$in": hookIDs}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list dead-letter deliveries"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []Delivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to decode deliveries"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// Emit looks up every active webhook an owner has registered for event and
+// queues a signed delivery for each. Queuing (rather than delivering
+// synchronously) keeps the emission point, e.g. CreateContract, from
+// blocking on a slow or unreachable endpoint; the background worker started
+// by RunDeliveryWorker does the actual POSTing with retries.
+func (s *Service) Emit(ctx context.Context, event string, ownerID string, payload interface{}) {
+	cursor, err := s.webhooks.Find(ctx, bson.M{"owner_id": ownerID, "active": true, "events": event})
+	if err != nil {
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var hooks []Webhook
+	if err := cursor.All(ctx, &hooks); err != nil {
+		return
+	}
+
+	env := envelope{
+		Event:     event,
+		ID:        uuid.New().String(),
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+	body, err := json.Marshal(env)
+	if err != nil {
+		return
+	}
+
+	for _, hook := range hooks {
+		delivery := Delivery{
+			ID:          uuid.New().String(),
+			WebhookID:   hook.ID,
+			Event:       event,
+			Envelope:    body,
+			Status:      "pending",
+			NextAttempt: time.Now(),
+			CreatedAt:   time.Now(),
+		}
+		_, _ = s.deliveries.InsertOne(ctx, delivery)
+	}
+}
+
+// sign computes the HMAC-SHA256 signature of body using secret, formatted
+// for the X-Signature header.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
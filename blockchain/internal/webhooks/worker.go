@@ -0,0 +1,111 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// RunDeliveryWorker polls due deliveries and attempts to POST them until
+// ctx is cancelled. Intended to be launched once as a background goroutine.
+func (s *Service) RunDeliveryWorker(ctx context.Context, pollInterval time.Duration) {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.processDueDeliveries(ctx)
+		}
+	}
+}
+
+func (s *Service) processDueDeliveries(ctx context.Context) {
+	cursor, err := s.deliveries.Find(ctx, bson.M{
+		"status":       "pending",
+		"next_attempt": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		log.Printf("webhooks: failed to load due deliveries: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var due []Delivery
+	if err := cursor.All(ctx, &due); err != nil {
+		log.Printf("webhooks: failed to decode due deliveries: %v", err)
+		return
+	}
+
+	for _, delivery := range due {
+		s.attemptDelivery(ctx, delivery)
+	}
+}
+
+func (s *Service) attemptDelivery(ctx context.Context, delivery Delivery) {
+	var hook Webhook
+	if err := s.webhooks.FindOne(ctx, bson.M{"_id": delivery.WebhookID}).Decode(&hook); err != nil {
+		// The webhook was deleted since the delivery was queued; nothing to
+		// retry against.
+		_, _ = s.deliveries.UpdateOne(ctx, bson.M{"_id": delivery.ID}, bson.M{"$set": bson.M{"status": "dead", "last_error": "webhook no longer exists"}})
+		return
+	}
+
+	err := s.deliverOnce(ctx, hook, delivery.Envelope)
+	delivery.Attempt++
+
+	if err == nil {
+		_, _ = s.deliveries.UpdateOne(ctx, bson.M{"_id": delivery.ID}, bson.M{"$set": bson.M{
+			"status":  "delivered",
+			"attempt": delivery.Attempt,
+		}})
+		return
+	}
+
+	if delivery.Attempt >= maxDeliveryAttempts {
+		_, _ = s.deliveries.UpdateOne(ctx, bson.M{"_id": delivery.ID}, bson.M{"$set": bson.M{
+			"status":     "dead",
+			"attempt":    delivery.Attempt,
+			"last_error": err.Error(),
+		}})
+		return
+	}
+
+	backoff := baseRetryDelay * time.Duration(1<<uint(delivery.Attempt-1))
+	_, _ = s.deliveries.UpdateOne(ctx, bson.M{"_id": delivery.ID}, bson.M{"$set": bson.M{
+		"status":       "pending",
+		"attempt":      delivery.Attempt,
+		"next_attempt": time.Now().Add(backoff),
+		"last_error":   err.Error(),
+	}})
+}
+
+func (s *Service) deliverOnce(ctx context.Context, hook Webhook, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature", sign(hook.Secret, body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("delivery failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -1,5 +1,21 @@
 package types
 
+import "math/big"
+
+// GasStrategy selects how a deployment's transaction fee is priced.
+type GasStrategy string
+
+const (
+	// GasStrategyLegacy sends a type-0 transaction priced with SuggestGasPrice.
+	GasStrategyLegacy GasStrategy = "legacy"
+	// GasStrategyEIP1559 sends a type-2 dynamic fee transaction, with the fee
+	// cap and tip derived automatically from the chain's base fee.
+	GasStrategyEIP1559 GasStrategy = "eip1559"
+	// GasStrategyManual sends a type-2 dynamic fee transaction using the
+	// caller-supplied MaxFeePerGas/MaxPriorityFeePerGas verbatim.
+	GasStrategyManual GasStrategy = "manual"
+)
+
 // DeploymentRequest represents a request to deploy a contract
 type DeploymentRequest struct {
 	ContractCode         string                 `json:"contract_code"`
@@ -7,6 +23,17 @@ type DeploymentRequest struct {
 	ChainID              int                    `json:"chain_id"`
 	GasLimit             uint64                 `json:"gas_limit"`
 	Metadata             map[string]interface{} `json:"metadata,omitempty"`
+	// Nonce pins the transaction to an explicit account nonce instead of
+	// letting the client query PendingNonceAt. Used by callers that need to
+	// precompute a CREATE address before submitting, such as bundle deploys.
+	Nonce *uint64 `json:"nonce,omitempty"`
+	// GasStrategy selects legacy, eip1559, or manual fee pricing. Defaults to
+	// GasStrategyLegacy when empty.
+	GasStrategy GasStrategy `json:"gas_strategy,omitempty"`
+	// MaxFeePerGas and MaxPriorityFeePerGas are required when GasStrategy is
+	// "manual" and ignored otherwise.
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas,omitempty"`
 }
 
 // DeploymentResponse represents the response after deploying a contract